@@ -19,11 +19,26 @@ Required permissions:
 - Gist (read/write) permission
 - repo permission (for accessing rule file list)
 
-The token is securely stored in ~/.rulesctl/config.json`,
+The token is securely stored in ~/.rulesctl/config.json
+
+Tokens are stored per GitHub host. To use a GitHub Enterprise Server,
+set "api_url" in the configuration or the GITHUB_API_URL environment variable
+(e.g. https://github.example.com/api/v3) before running auth.
+The token is then used only for that host, so github.com and
+GitHub Enterprise Server can be used side by side.
+
+Use --gitlab flag to store a GitLab Personal Access Token (api scope)
+for the gitlab backend. The GitLab instance is set with "gitlab_url"
+in the configuration or the GITLAB_URL environment variable (default: https://gitlab.com).`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		token, _ := cmd.Flags().GetString("token")
+		gitlab, _ := cmd.Flags().GetBool("gitlab")
 		if token == "" {
-			fmt.Print("Enter GitHub Personal Access Token: ")
+			if gitlab {
+				fmt.Print("Enter GitLab Personal Access Token: ")
+			} else {
+				fmt.Print("Enter GitHub Personal Access Token: ")
+			}
 			tokenBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
 			fmt.Println() // Add newline
 			if err != nil {
@@ -36,10 +51,26 @@ The token is securely stored in ~/.rulesctl/config.json`,
 			return fmt.Errorf("token not provided")
 		}
 
+		if gitlab {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+			if err := config.SaveHostToken(cfg.GitLabHost(), token); err != nil {
+				return fmt.Errorf("failed to save token: %w", err)
+			}
+			fmt.Printf("Token saved successfully for %s.\n", cfg.GitLabHost())
+			return nil
+		}
+
 		if err := config.SaveToken(token); err != nil {
 			return fmt.Errorf("failed to save token: %w", err)
 		}
 
+		if cfg, err := config.LoadConfig(); err == nil && cfg.IsEnterprise() {
+			fmt.Printf("Token saved successfully for %s.\n", cfg.APIHost())
+			return nil
+		}
 		fmt.Println("Token saved successfully.")
 		return nil
 	},
@@ -48,4 +79,5 @@ The token is securely stored in ~/.rulesctl/config.json`,
 func init() {
 	rootCmd.AddCommand(authCmd)
 	authCmd.Flags().StringP("token", "t", "", "GitHub Personal Access Token")
+	authCmd.Flags().Bool("gitlab", false, "Store a GitLab token for the gitlab backend")
 } 
\ No newline at end of file
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/choigawoon/rulesctl/internal/backend"
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/choigawoon/rulesctl/internal/lockfile"
+	"github.com/choigawoon/rulesctl/pkg/config"
+)
+
+// openBackend opens the storage backend with the given name.
+// An empty name opens the backend selected with --backend or in the configuration.
+// location, if not empty, is the location recorded in the lock file for an installed
+// rule set (see backend.Locator) and takes precedence over the configured one.
+// Tests replace it to run commands against an in-memory backend.
+var openBackend = func(name, location string) (backend.Backend, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if name == "" {
+		name = backendName
+	}
+	switch {
+	case name == "http" && location != "":
+		cfg.RegistryURL = location
+	case name == "gist":
+		// Store rule sets come from github.com even if a GitHub Enterprise Server is configured.
+		// The token of the configured host is not sent to another host.
+		gist.SetAPIBaseURL(location)
+		if location != "" && strings.TrimSuffix(location, "/") != cfg.APIBaseURL() {
+			cfg.Token = ""
+		}
+	}
+	return backend.New(name, cfg)
+}
+
+// resolveRuleset returns the rule set ID given with --gistid,
+// or searches the backend for the title given as the first argument.
+func resolveRuleset(b backend.Backend, args []string, id string) (string, error) {
+	if id != "" {
+		// ID given directly (public gist, token optional)
+		return id, nil
+	}
+
+	if len(args) == 0 {
+		return "", fmt.Errorf("please specify a title or use --gistid option")
+	}
+
+	rs, err := backend.FindByTitle(b, args[0])
+	if err != nil {
+		return "", err
+	}
+	return rs.ID, nil
+}
+
+// fetchSnapshot fetches a rule set at the given revision (number or version).
+// An empty revision fetches the latest revision.
+func fetchSnapshot(b backend.Backend, id, revision string) (*backend.Snapshot, error) {
+	if revision == "" {
+		return b.Get(id, "")
+	}
+
+	history, err := b.History(id)
+	if err != nil {
+		return nil, err
+	}
+	version, err := backend.ResolveRevision(history, revision)
+	if err != nil {
+		return nil, err
+	}
+	return b.Get(id, version)
+}
+
+// recordInstall records an installed rule set in the lock file.
+func recordInstall(b backend.Backend, snap *backend.Snapshot) error {
+	lock, err := lockfile.Load()
+	if err != nil {
+		return err
+	}
+
+	lock.Record(lockEntry(b, snap))
+	if err := lock.Save(); err != nil {
+		return fmt.Errorf("failed to update lock file: %w", err)
+	}
+	return nil
+}
+
+// lockEntry returns the lock file entry of an installed rule set snapshot.
+func lockEntry(b backend.Backend, snap *backend.Snapshot) lockfile.Ruleset {
+	entry := lockfile.Ruleset{
+		Backend: b.Name(),
+		ID:      snap.ID,
+		Version: snap.Version,
+		Title:   snap.Title,
+		Files:   append([]gist.FileMetadata(nil), snap.Meta.Files...),
+	}
+	if l, ok := b.(backend.Locator); ok {
+		entry.Location = l.Location()
+	}
+	return entry
+}
+
+// useRulesetDir switches to the rules directory configured for a rule set in .rulesctl.json,
+// unless a directory was given with --dir. The returned function restores the previous directory.
+func useRulesetDir(title string) (func(), error) {
+	if fileutils.RulesDirOverridden() {
+		return func() {}, nil
+	}
+	dir, err := fileutils.RulesetDir(title)
+	if err != nil || dir == "" {
+		return func() {}, err
+	}
+	fileutils.SetRulesDir(dir)
+	return func() { fileutils.SetRulesDir("") }, nil
+}
+
+// installSnapshot installs the files of a rule set snapshot into its rules directory
+// after checking for conflicts, and records it in the lock file of that directory.
+func installSnapshot(b backend.Backend, snap *backend.Snapshot, force bool) error {
+	restore, err := useRulesetDir(snap.Title)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	if fileutils.RulesDirOverridden() {
+		fmt.Printf("Installing into %s\n", fileutils.DisplayRulesDir())
+	}
+	if err := installFiles(snap.Meta, snap.Read, force); err != nil {
+		return err
+	}
+
+	// Record installed rule set in lock file
+	return recordInstall(b, snap)
+}
+
+// installFiles installs rule files into the rules directory after checking for conflicts.
+// Every file is verified against its MD5 hash before anything is written.
+func installFiles(meta *gist.Metadata, read gist.FileReader, force bool) error {
+	// Check for file conflicts
+	if !force {
+		conflicts, err := gist.CheckConflicts(meta)
+		if err != nil {
+			return fmt.Errorf("failed to check conflicts: %w", err)
+		}
+		if len(conflicts) > 0 {
+			fmt.Println("The following files already exist:")
+			for _, path := range conflicts {
+				fmt.Printf("  - %s\n", path)
+			}
+			return fmt.Errorf("file conflicts detected. Use --force option to overwrite")
+		}
+	}
+
+	if err := gist.InstallFiles(meta, read, force); err != nil {
+		return fmt.Errorf("failed to download: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/choigawoon/rulesctl/internal/backend"
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/choigawoon/rulesctl/internal/lockfile"
+)
+
+// useMemoryBackend runs commands against an in-memory backend in an empty working directory.
+func useMemoryBackend(t *testing.T) *backend.Memory {
+	t.Helper()
+
+	mem := backend.NewMemory()
+	original := openBackend
+	openBackend = func(name, location string) (backend.Backend, error) {
+		return mem, nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("작업 디렉토리 확인 실패: %v", err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("작업 디렉토리 변경 실패: %v", err)
+	}
+
+	t.Cleanup(func() {
+		openBackend = original
+		os.Chdir(wd)
+	})
+	return mem
+}
+
+func TestUploadDownloadRoundTrip(t *testing.T) {
+	mem := useMemoryBackend(t)
+
+	rulesDir := filepath.Join(".cursor", "rules")
+	if err := os.MkdirAll(filepath.Join(rulesDir, "python"), 0755); err != nil {
+		t.Fatalf("룰 디렉토리 생성 실패: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, "python", "lint.mdc"), []byte("lint rules"), 0644); err != nil {
+		t.Fatalf("룰 파일 생성 실패: %v", err)
+	}
+
+	if err := uploadCmd.RunE(uploadCmd, []string{"test-rules"}); err != nil {
+		t.Fatalf("upload 실패: %v", err)
+	}
+
+	rs, err := backend.FindByTitle(mem, "test-rules")
+	if err != nil {
+		t.Fatalf("업로드된 룰셋을 찾을 수 없습니다: %v", err)
+	}
+
+	// Download into a clean rules directory
+	if err := os.RemoveAll(rulesDir); err != nil {
+		t.Fatalf("룰 디렉토리 삭제 실패: %v", err)
+	}
+	if err := downloadCmd.RunE(downloadCmd, []string{"test-rules"}); err != nil {
+		t.Fatalf("download 실패: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(rulesDir, "python", "lint.mdc"))
+	if err != nil {
+		t.Fatalf("다운로드된 파일 읽기 실패: %v", err)
+	}
+	if string(content) != "lint rules" {
+		t.Errorf("파일 내용 = %q; want %q", content, "lint rules")
+	}
+
+	lock, err := lockfile.Load()
+	if err != nil {
+		t.Fatalf("lock 파일 로드 실패: %v", err)
+	}
+	entry := lock.Find("memory", rs.ID)
+	if entry == nil {
+		t.Fatal("다운로드한 룰셋이 lock 파일에 기록되지 않았습니다")
+	}
+	if entry.Version != "rev1" {
+		t.Errorf("lock 버전 = %s; want rev1", entry.Version)
+	}
+}
+
+func TestDownloadRulesetDir(t *testing.T) {
+	useMemoryBackend(t)
+
+	rulesDir := filepath.Join(".cursor", "rules")
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		t.Fatalf("룰 디렉토리 생성 실패: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, "api.mdc"), []byte("api rules"), 0644); err != nil {
+		t.Fatalf("룰 파일 생성 실패: %v", err)
+	}
+	if err := uploadCmd.RunE(uploadCmd, []string{"api-rules"}); err != nil {
+		t.Fatalf("upload 실패: %v", err)
+	}
+
+	// 룰셋별 디렉토리 설정
+	cfg := &fileutils.ProjectConfig{Rulesets: map[string]string{"api-rules": "services/api/.cursor/rules"}}
+	if err := fileutils.SaveProjectConfig(".", cfg); err != nil {
+		t.Fatalf("프로젝트 설정 저장 실패: %v", err)
+	}
+	if err := downloadCmd.RunE(downloadCmd, []string{"api-rules"}); err != nil {
+		t.Fatalf("download 실패: %v", err)
+	}
+
+	apiDir := filepath.Join("services", "api", ".cursor", "rules")
+	content, err := os.ReadFile(filepath.Join(apiDir, "api.mdc"))
+	if err != nil {
+		t.Fatalf("룰셋 디렉토리에 설치되지 않았습니다: %v", err)
+	}
+	if string(content) != "api rules" {
+		t.Errorf("파일 내용 = %q; want %q", content, "api rules")
+	}
+	if _, err := os.Stat(filepath.Join(apiDir, ".rulesctl.lock")); err != nil {
+		t.Errorf("룰셋 디렉토리에 lock 파일이 없습니다: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(rulesDir, ".rulesctl.lock")); err == nil {
+		t.Error("기본 룰 디렉토리에 lock 파일이 기록되었습니다")
+	}
+
+	if err := pullCmd.RunE(pullCmd, nil); err != nil {
+		t.Fatalf("pull 실패: %v", err)
+	}
+}
+
+func TestUploadPreviewBrokenRule(t *testing.T) {
+	mem := useMemoryBackend(t)
+
+	rulesDir := filepath.Join(".cursor", "rules")
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		t.Fatalf("룰 디렉토리 생성 실패: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, "good.mdc"), []byte("---\nalwaysApply: true\n---\nbody\n"), 0644); err != nil {
+		t.Fatalf("룰 파일 생성 실패: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, "broken.mdc"), []byte("---\ndescription: x\n"), 0644); err != nil {
+		t.Fatalf("룰 파일 생성 실패: %v", err)
+	}
+
+	preview = true
+	defer func() { preview = false }()
+	if err := uploadCmd.RunE(uploadCmd, []string{"preview-rules"}); err != nil {
+		t.Fatalf("깨진 룰이 있어도 preview는 실패하지 않아야 합니다: %v", err)
+	}
+	if _, err := backend.FindByTitle(mem, "preview-rules"); err == nil {
+		t.Error("preview에서 룰셋이 업로드되었습니다")
+	}
+}
+
+func TestPullFromRegistry(t *testing.T) {
+	open := openBackend
+	mem := useMemoryBackend(t)
+	t.Setenv("RULESCTL_REGISTRY_URL", "")
+
+	rulesDir := filepath.Join(".cursor", "rules")
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		t.Fatalf("룰 디렉토리 생성 실패: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, "api.mdc"), []byte("v1"), 0644); err != nil {
+		t.Fatalf("룰 파일 생성 실패: %v", err)
+	}
+	if err := uploadCmd.RunE(uploadCmd, []string{"api-rules"}); err != nil {
+		t.Fatalf("upload 실패: %v", err)
+	}
+
+	registry := t.TempDir()
+	if _, err := backend.BuildRegistry(mem, registry, nil); err != nil {
+		t.Fatalf("BuildRegistry 실패: %v", err)
+	}
+	server := httptest.NewServer(http.FileServer(http.Dir(registry)))
+	defer server.Close()
+
+	// 레지스트리에서 설치한 룰셋은 설정 없이도 같은 레지스트리에서 갱신
+	openBackend = open
+	if err := os.RemoveAll(rulesDir); err != nil {
+		t.Fatalf("룰 디렉토리 삭제 실패: %v", err)
+	}
+	storeDownloadCmd.Flags().Set("registry", server.URL)
+	defer storeDownloadCmd.Flags().Set("registry", "")
+	if err := storeDownloadCmd.RunE(storeDownloadCmd, []string{"api-rules"}); err != nil {
+		t.Fatalf("store download 실패: %v", err)
+	}
+
+	lock, err := lockfile.Load()
+	if err != nil || len(lock.Rulesets) != 1 || lock.Rulesets[0].Location != server.URL {
+		t.Fatalf("lock = %+v, %v; want the registry URL %s", lock, err, server.URL)
+	}
+
+	files := map[string][]byte{"api.mdc": []byte("v2")}
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "api.mdc"), files["api.mdc"], 0644); err != nil {
+		t.Fatalf("룰 파일 생성 실패: %v", err)
+	}
+	meta, err := gist.PreviewMetadataFromDir(src)
+	if err != nil {
+		t.Fatalf("메타데이터 생성 실패: %v", err)
+	}
+	if _, err := mem.Put("api-rules", meta, files, backend.PutOptions{Force: true}); err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if _, err := backend.BuildRegistry(mem, registry, nil); err != nil {
+		t.Fatalf("BuildRegistry 실패: %v", err)
+	}
+
+	if err := pullCmd.RunE(pullCmd, nil); err != nil {
+		t.Fatalf("pull 실패: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(rulesDir, "api.mdc"))
+	if err != nil || string(content) != "v2" {
+		t.Errorf("파일 내용 = %q, %v; want v2", content, err)
+	}
+}
+
+func TestOpenBackendGistLocation(t *testing.T) {
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	// GitHub Enterprise Server가 설정된 환경에서 github.com 스토어 룰셋을 갱신하는 경우
+	t.Setenv("GITHUB_API_URL", "https://github.example.com/api/v3")
+	t.Setenv("GITHUB_TOKEN", "enterprise-token")
+	defer gist.SetAPIBaseURL("")
+
+	b, err := openBackend("gist", server.URL)
+	if err != nil {
+		t.Fatalf("openBackend 실패: %v", err)
+	}
+	if l, ok := b.(backend.Locator); !ok || l.Location() != server.URL {
+		t.Errorf("Location = %v; want %s", b, server.URL)
+	}
+	b.Get("abc123", "")
+	if len(auth) == 0 || auth[0] != "" {
+		t.Errorf("Authorization = %q; 설정된 호스트의 토큰을 다른 호스트로 보내면 안 됩니다", auth)
+	}
+
+	// 위치가 기록되지 않은 항목은 설정된 호스트를 사용
+	b, err = openBackend("gist", "")
+	if err != nil {
+		t.Fatalf("openBackend 실패: %v", err)
+	}
+	if l := b.(backend.Locator); l.Location() != "https://github.example.com/api/v3" {
+		t.Errorf("Location = %s; want the configured API URL", l.Location())
+	}
+}
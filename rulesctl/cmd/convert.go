@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/choigawoon/rulesctl/internal/convert"
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/rule"
+	"github.com/spf13/cobra"
+)
+
+var (
+	convertTargets []string
+	convertForce   bool
+	convertDryRun  bool
+)
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Render rules into the formats of other AI assistants",
+	Long: `Render the .mdc files in .cursor/rules into the instruction files of other AI assistants,
+so .cursor/rules stays the single source of truth.
+
+Targets:
+  copilot   .github/copilot-instructions.md, and .github/instructions/*.instructions.md
+            with applyTo for rules with globs
+  claude    CLAUDE.md
+  agents    AGENTS.md
+  windsurf  .windsurfrules
+  cline     .clinerules/*.md, with paths for rules with globs
+
+Rule frontmatter is kept where the target can express it. Otherwise globs,
+descriptions and manual rules are written as a note above the rule.
+
+Generated files are marked, and only generated files are overwritten or removed.
+Use --force to overwrite hand-written files.
+
+Examples:
+  rulesctl convert --to claude
+  rulesctl convert --to copilot,agents
+  rulesctl convert --to cline --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(convertTargets) == 0 {
+			return fmt.Errorf("please specify a target with --to (%s)", strings.Join(convert.Targets(), ", "))
+		}
+
+		rulesDir, err := fileutils.GetRulesDirPath()
+		if err != nil {
+			return err
+		}
+		rules, broken, err := rule.Load(rulesDir)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		printBroken(broken)
+		if len(rules) == 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("no rule files found in %s", rulesDir)
+		}
+
+		// Instruction files are written to the project root, wherever rulesctl is run from
+		workDir, err := fileutils.ProjectRoot()
+		if err != nil {
+			return err
+		}
+
+		for _, name := range convertTargets {
+			result, err := convert.Convert(name, rules)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("%s:\n", convert.Describe(name))
+			for _, file := range result.Files {
+				fmt.Printf("  %s\n", file.Path)
+			}
+			for _, warning := range result.Warnings {
+				fmt.Printf("  warning: %s\n", warning)
+			}
+			if convertDryRun {
+				continue
+			}
+
+			removed, err := convert.Write(workDir, result, convertForce)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			for _, path := range removed {
+				fmt.Printf("  removed: %s\n", path)
+			}
+		}
+
+		if convertDryRun {
+			fmt.Println("Dry run: no files were written.")
+		} else {
+			fmt.Printf("Converted %d rules.\n", len(rules))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+	convertCmd.Flags().StringSliceVar(&convertTargets, "to", nil, "Target formats: "+strings.Join(convert.Targets(), ", "))
+	convertCmd.Flags().BoolVarP(&convertForce, "force", "f", false, "Overwrite files that were not generated by rulesctl")
+	convertCmd.Flags().BoolVar(&convertDryRun, "dry-run", false, "Show the files that would be written without writing them")
+}
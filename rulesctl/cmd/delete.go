@@ -4,14 +4,14 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/choigawoon/rulesctl/internal/backend"
 	"github.com/spf13/cobra"
 )
 
 var deleteCmd = &cobra.Command{
 	Use:           "delete [name]",
 	Short:         "Delete a rule set",
-	Long: `Delete a rule set stored in GitHub Gist.
+	Long: `Delete a rule set stored in GitHub Gist (or the backend selected with --backend).
 Rule sets are deleted by searching for their title.
 Only rule sets uploaded within the last month can be deleted.
 
@@ -24,26 +24,17 @@ Examples:
 		force, _ := cmd.Flags().GetBool("force")
 		title := args[0]
 
-		// Fetch all Gists
-		gists, err := gist.FetchUserGists(nil)
+		b, err := openBackend("", "")
 		if err != nil {
-			return fmt.Errorf("failed to fetch Gist list: %w", err)
-		}
-
-		// Find Gist by title
-		var targetGist gist.Gist
-		found := false
-		for _, g := range gists {
-			if g.Description == title {
-				targetGist = g
-				found = true
-				break
-			}
+			cmd.SilenceUsage = true
+			return err
 		}
 
-		if !found {
+		// Find rule set by title
+		target, err := backend.FindByTitle(b, title)
+		if err != nil {
 			cmd.SilenceUsage = true
-			return fmt.Errorf("rule set not found: %s", title)
+			return err
 		}
 
 		// Confirm before deletion
@@ -57,9 +48,9 @@ Examples:
 			}
 		}
 
-		if err := gist.DeleteGist(targetGist.ID); err != nil {
+		if err := b.Delete(target.ID); err != nil {
 			cmd.SilenceUsage = true
-			return fmt.Errorf("failed to delete Gist: %w", err)
+			return err
 		}
 
 		fmt.Printf("Rule set '%s' has been deleted.\n", title)
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/choigawoon/rulesctl/internal/backend"
+	"github.com/choigawoon/rulesctl/internal/diff"
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffGistID   string
+	diffNameOnly bool
+	diffRevs     string
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [title]",
+	Short: "Show differences between local rules and a remote rule set",
+	Long: `Compare the local .cursor/rules directory with a rule set stored in the backend.
+Files are compared using the MD5 hashes in the rule set metadata.
+
+Added files exist only in the remote rule set, removed files exist only locally,
+and modified files are shown with a unified diff (local -> remote).
+
+Use --rev <from>..<to> to compare two revisions of the remote rule set instead.
+Revisions are given as numbers (1 is the first revision) or version SHAs.
+
+Examples:
+  rulesctl diff "python-linting-rules"
+  rulesctl diff --gistid abc123
+  rulesctl diff "python-linting-rules" --name-only
+  rulesctl diff "python-linting-rules" --rev 3..1`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := openBackend("", "")
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		targetID, err := resolveRuleset(b, args, diffGistID)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		if diffRevs != "" {
+			cmd.SilenceUsage = true
+			return diffRevisions(b, targetID, diffRevs)
+		}
+
+		// Fetch rule set
+		snap, err := b.Get(targetID, "")
+		if err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("failed to fetch rule set: %w", err)
+		}
+		meta := snap.Meta
+
+		// Collect local rule hashes
+		local, err := fileutils.ListLocalRules()
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		rulesDir, err := fileutils.GetRulesDirPath()
+		if err != nil {
+			return err
+		}
+
+		changes := gist.CompareMetadata(gist.MetadataFromHashes(local), meta)
+		if changes.IsEmpty() {
+			fmt.Println("No differences found.")
+			return nil
+		}
+
+		printChangeSet(changes)
+
+		if diffNameOnly || len(changes.Modified) == 0 {
+			return nil
+		}
+
+		// Show content differences for modified files
+		for _, file := range changes.Modified {
+			localContent, err := os.ReadFile(filepath.Join(rulesDir, file.Path))
+			if err != nil {
+				return fmt.Errorf("failed to read file %s: %w", file.Path, err)
+			}
+
+			remoteContent, err := snap.Read(file)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			fmt.Println()
+			fmt.Print(diff.Unified("local/"+file.Path, "remote/"+file.Path, string(localContent), string(remoteContent), diff.DefaultContext))
+		}
+
+		return nil
+	},
+}
+
+// diffRevisions prints the differences between two revisions ("from..to") of a rule set.
+func diffRevisions(b backend.Backend, id, revs string) error {
+	parts := strings.Split(revs, "..")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid revision range: %s (expected <from>..<to>)", revs)
+	}
+
+	var snaps [2]*backend.Snapshot
+	var versions [2]string
+	for i, rev := range parts {
+		snap, err := fetchSnapshot(b, id, rev)
+		if err != nil {
+			return fmt.Errorf("failed to fetch revision %s: %w", rev, err)
+		}
+		snaps[i], versions[i] = snap, snap.Version
+	}
+	metas := [2]*gist.Metadata{snaps[0].Meta, snaps[1].Meta}
+
+	fromName, toName := shortVersion(versions[0]), shortVersion(versions[1])
+	fmt.Printf("Comparing revision %s -> %s\n", fromName, toName)
+
+	changes := gist.CompareMetadata(metas[0], metas[1])
+	if changes.IsEmpty() {
+		fmt.Println("No differences found.")
+		return nil
+	}
+
+	printChangeSet(changes)
+
+	if diffNameOnly {
+		return nil
+	}
+
+	// Map paths to the file entries of the base revision
+	fromFiles := make(map[string]gist.FileMetadata)
+	for _, file := range metas[0].Files {
+		fromFiles[file.Path] = file
+	}
+
+	for _, file := range changes.Modified {
+		fromContent, err := snaps[0].Read(fromFiles[file.Path])
+		if err != nil {
+			return err
+		}
+		toContent, err := snaps[1].Read(file)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Print(diff.Unified(fromName+"/"+file.Path, toName+"/"+file.Path, string(fromContent), string(toContent), diff.DefaultContext))
+	}
+
+	return nil
+}
+
+// printChangeSet prints added, removed and modified files of a change set.
+func printChangeSet(changes *gist.ChangeSet) {
+	for _, file := range changes.Added {
+		fmt.Printf("  added:    %s\n", file.Path)
+	}
+	for _, file := range changes.Removed {
+		fmt.Printf("  removed:  %s\n", file.Path)
+	}
+	for _, file := range changes.Modified {
+		fmt.Printf("  modified: %s\n", file.Path)
+	}
+	fmt.Printf("%d added, %d removed, %d modified\n", len(changes.Added), len(changes.Removed), len(changes.Modified))
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVar(&diffGistID, "gistid", "", "Rule set ID to compare with")
+	diffCmd.Flags().BoolVar(&diffNameOnly, "name-only", false, "Show only changed file names")
+	diffCmd.Flags().StringVar(&diffRevs, "rev", "", "Compare two revisions (<from>..<to>) instead of local files")
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiffEmptyRulesDir(t *testing.T) {
+	useMemoryBackend(t)
+
+	rulesDir := filepath.Join(".cursor", "rules")
+	if err := os.MkdirAll(filepath.Join(rulesDir, "python"), 0755); err != nil {
+		t.Fatalf("룰 디렉토리 생성 실패: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, "python", "lint.mdc"), []byte("lint rules"), 0644); err != nil {
+		t.Fatalf("룰 파일 생성 실패: %v", err)
+	}
+	if err := uploadCmd.RunE(uploadCmd, []string{"test-rules"}); err != nil {
+		t.Fatalf("upload 실패: %v", err)
+	}
+
+	// 새 프로젝트에서는 모든 원격 파일이 추가된 것으로 표시
+	if err := os.RemoveAll(filepath.Join(rulesDir, "python")); err != nil {
+		t.Fatalf("룰 파일 삭제 실패: %v", err)
+	}
+	if err := diffCmd.RunE(diffCmd, []string{"test-rules"}); err != nil {
+		t.Errorf("빈 룰 디렉토리에 대한 diff 실패: %v", err)
+	}
+	if err := os.RemoveAll(rulesDir); err != nil {
+		t.Fatalf("룰 디렉토리 삭제 실패: %v", err)
+	}
+	if err := diffCmd.RunE(diffCmd, []string{"test-rules"}); err != nil {
+		t.Errorf("룰 디렉토리가 없을 때 diff 실패: %v", err)
+	}
+}
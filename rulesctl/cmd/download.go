@@ -3,12 +3,11 @@ package cmd
 import (
 	"fmt"
 	"github.com/spf13/cobra"
-	"github.com/choigawoon/rulesctl/internal/gist"
-	"github.com/choigawoon/rulesctl/pkg/config"
 )
 
 var (
-	gistID string
+	gistID           string
+	downloadRevision string
 )
 
 var downloadCmd = &cobra.Command{
@@ -16,6 +15,7 @@ var downloadCmd = &cobra.Command{
 	Short: "Download rules from GIST",
 	Long: `Download rules from GIST to .cursor/rules directory using title or Gist ID.
 Use --force option to overwrite existing files.
+The installed rule set is recorded in .cursor/rules/.rulesctl.lock.
 
 Examples:
   # Download by title (search in your Gists)
@@ -24,99 +24,41 @@ Examples:
 
   # Download by Gist ID (public Gist, no token needed)
   rulesctl download --gistid abc123
-  rulesctl download --gistid abc123 --force`,
+  rulesctl download --gistid abc123 --force
+
+  # Download a specific revision (1 is the first revision, or a version SHA)
+  rulesctl download "python-linting-rules" --revision 2
+  rulesctl download --gistid abc123 --revision 3f2a9c1`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var token string
-		var targetGistID string
-
-		// Load configuration
-		cfg, err := config.LoadConfig()
-		if err == nil {
-			token = cfg.Token
-		}
-
-		if gistID != "" {
-			// Download by Gist ID (public gist, token optional)
-			targetGistID = gistID
-		} else {
-			// Download by title (requires token)
-			if token == "" {
-				cmd.SilenceUsage = true
-				return fmt.Errorf("GitHub token not set. Please run 'rulesctl auth' to set your token")
-			}
-
-			if len(args) == 0 {
-				cmd.SilenceUsage = true
-				return fmt.Errorf("please specify a title or use --gistid option")
-			}
-			title := args[0]
-
-			// Fetch all Gists
-			gists, err := gist.FetchUserGists(nil)
-			if err != nil {
-				cmd.SilenceUsage = true
-				return fmt.Errorf("failed to fetch Gist list: %w", err)
-			}
-
-			// Find Gist by title
-			found := false
-			for _, g := range gists {
-				if g.Description == title {
-					targetGistID = g.ID
-					found = true
-					break
-				}
-			}
-
-			if !found {
-				cmd.SilenceUsage = true
-				return fmt.Errorf("no Gist found with title: %s", title)
-			}
-		}
-
-		// Fetch Gist
-		g, err := gist.FetchGist(token, targetGistID)
+		b, err := openBackend("", "")
 		if err != nil {
 			cmd.SilenceUsage = true
-			return fmt.Errorf("failed to fetch Gist: %w", err)
+			return err
 		}
 
-		// Check .rulesctl.meta.json file
-		metaFile, exists := g.Files[gist.MetaFileName]
-		if !exists {
+		targetID, err := resolveRuleset(b, args, gistID)
+		if err != nil {
 			cmd.SilenceUsage = true
-			return fmt.Errorf("this Gist is not managed by rulesctl (no metadata file)")
+			return err
 		}
 
-		// Parse metadata
-		meta, err := gist.ParseMetadataFromGist(metaFile.Content)
+		// Fetch rule set
+		snap, err := fetchSnapshot(b, targetID, downloadRevision)
 		if err != nil {
 			cmd.SilenceUsage = true
-			return fmt.Errorf("failed to parse metadata: %w", err)
-		}
-
-		// Check for file conflicts
-		if !force {
-			conflicts, err := gist.CheckConflicts(meta)
-			if err != nil {
-				cmd.SilenceUsage = true
-				return fmt.Errorf("failed to check conflicts: %w", err)
-			}
-			if len(conflicts) > 0 {
-				fmt.Println("The following files already exist:")
-				for _, path := range conflicts {
-					fmt.Printf("  - %s\n", path)
-				}
-				cmd.SilenceUsage = true
-				return fmt.Errorf("file conflicts detected. Use --force option to overwrite")
-			}
+			return err
 		}
 
 		// Download files
-		fmt.Printf("Downloading rules... (Gist ID: %s)\n", targetGistID)
-		if err := gist.DownloadFiles(token, targetGistID, meta, force); err != nil {
-			return fmt.Errorf("failed to download: %w", err)
+		if downloadRevision != "" {
+			fmt.Printf("Downloading rules... (ID: %s, revision: %s)\n", targetID, shortVersion(snap.Version))
+		} else {
+			fmt.Printf("Downloading rules... (ID: %s)\n", targetID)
+		}
+		if err := installSnapshot(b, snap, force); err != nil {
+			cmd.SilenceUsage = true
+			return err
 		}
 
 		fmt.Println("Download completed successfully.")
@@ -127,4 +69,5 @@ Examples:
 func init() {
 	rootCmd.AddCommand(downloadCmd)
 	downloadCmd.Flags().StringVar(&gistID, "gistid", "", "Gist ID to download")
-} 
\ No newline at end of file
+	downloadCmd.Flags().StringVar(&downloadRevision, "revision", "", "Revision number or version SHA to download")
+}
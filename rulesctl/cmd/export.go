@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/choigawoon/rulesctl/internal/archive"
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportGistID   string
+	exportRevision string
+	exportLocal    bool
+	exportOutput   string
+	exportForce    bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [title]",
+	Short: "Export a rule set to a .tar.gz or .zip archive",
+	Long: `Export a rule set to a portable archive that can be shared without Gist access,
+for example attached to a ticket. The archive contains the rule files and
+.rulesctl.meta.json with their MD5 hashes, and is installed with 'rulesctl import'.
+
+The format is chosen by the extension of --output: .tar.gz, .tgz or .zip.
+Without --output, <ID>.tar.gz (or rules.tar.gz with --local) is written.
+
+Examples:
+  rulesctl export "python-linting-rules" -o python.zip
+  rulesctl export --gistid abc123 --revision 2
+  rulesctl export --local -o my-rules.tar.gz   # Export .cursor/rules`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var meta *gist.Metadata
+		var read gist.FileReader
+		output := exportOutput
+
+		if exportLocal {
+			if len(args) > 0 || exportGistID != "" {
+				return fmt.Errorf("--local cannot be combined with a title or --gistid")
+			}
+			rulesDir, err := fileutils.GetRulesDirPath()
+			if err != nil {
+				return err
+			}
+			meta, err = gist.PreviewMetadataFromWorkingDir()
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			read = func(file gist.FileMetadata) ([]byte, error) {
+				return os.ReadFile(filepath.Join(rulesDir, filepath.FromSlash(file.Path)))
+			}
+			if output == "" {
+				output = "rules.tar.gz"
+			}
+		} else {
+			b, err := openBackend("", "")
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			targetID, err := resolveRuleset(b, args, exportGistID)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			snap, err := fetchSnapshot(b, targetID, exportRevision)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			meta, read = snap.Meta, snap.Read
+			if output == "" {
+				output = targetID + ".tar.gz"
+			}
+		}
+
+		if len(meta.Files) == 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("no rule files to export")
+		}
+		if _, err := archive.FormatOf(output); err != nil {
+			return err
+		}
+		if _, err := os.Stat(output); err == nil && !exportForce {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%s already exists. Use --force option to overwrite", output)
+		}
+
+		if err := archive.Write(output, meta, read); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		fmt.Printf("Exported %d files to %s\n", len(meta.Files), output)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVar(&exportGistID, "gistid", "", "Gist ID of the rule set to export")
+	exportCmd.Flags().StringVar(&exportRevision, "revision", "", "Revision number or version SHA to export")
+	exportCmd.Flags().BoolVar(&exportLocal, "local", false, "Export the rule files in .cursor/rules")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Archive file to write (.tar.gz, .tgz or .zip)")
+	exportCmd.Flags().BoolVarP(&exportForce, "force", "f", false, "Overwrite the archive if it exists")
+}
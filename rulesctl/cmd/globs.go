@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/glob"
+	"github.com/choigawoon/rulesctl/internal/rule"
+	"github.com/spf13/cobra"
+)
+
+var (
+	globsGistID    string
+	globsRevision  string
+	globsFiles     bool
+	globsUncovered bool
+)
+
+var globsCmd = &cobra.Command{
+	Use:   "globs [title]",
+	Short: "Show which project files the globs of each rule attach to",
+	Long: `Match the globs of every rule against the files of the project and show
+how many files each glob attaches the rule to. Files ignored by .gitignore
+are skipped.
+
+Rules whose globs match no file are reported as dead, and the files
+no rule with globs covers are counted.
+
+Without a title, the rules in .cursor/rules are checked. With a title or
+--gistid, the rules of a rule set are checked before downloading it.
+
+A glob without a slash matches file names at any depth, e.g. *.py;
+a glob with a slash is matched from the project root, e.g. src/**/*.ts.
+
+Examples:
+  rulesctl globs
+  rulesctl globs --files --uncovered
+  rulesctl globs "python-linting-rules"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var rules []*rule.Rule
+		var broken []error
+		if len(args) > 0 || globsGistID != "" {
+			b, err := openBackend("", "")
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			targetID, err := resolveRuleset(b, args, globsGistID)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			snap, err := fetchSnapshot(b, targetID, globsRevision)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			if rules, broken, err = parseRules(snap.Meta, snap.Read); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+		} else {
+			rulesDir, err := fileutils.GetRulesDirPath()
+			if err != nil {
+				return err
+			}
+			if rules, broken, err = rule.Load(rulesDir); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+		}
+		printBroken(broken)
+
+		// Globs are relative to the project root, wherever rulesctl is run from
+		root, err := fileutils.ProjectRoot()
+		if err != nil {
+			return err
+		}
+		rulesDir, err := fileutils.GetRulesDirPath()
+		if err != nil {
+			return err
+		}
+		rulesPrefix, err := filepath.Rel(root, rulesDir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve rules directory: %w", err)
+		}
+		projectFiles, err := glob.Files(root)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		// Rule files themselves are not project files the rules are meant for
+		var files []string
+		for _, file := range projectFiles {
+			if !strings.HasPrefix(file, filepath.ToSlash(rulesPrefix)+"/") {
+				files = append(files, file)
+			}
+		}
+
+		covered := make(map[string]bool)
+		var dead []string
+		for _, r := range rules {
+			if len(r.Globs) == 0 {
+				continue
+			}
+
+			matched := make(map[string]bool)
+			var lines []string
+			for _, g := range r.Globs {
+				count := 0
+				for _, file := range files {
+					if glob.Match(g, file) {
+						count++
+						matched[file] = true
+						covered[file] = true
+					}
+				}
+				lines = append(lines, fmt.Sprintf("    %s: %d files", g, count))
+			}
+
+			fmt.Printf("%s: %d files\n", r.Path, len(matched))
+			for _, line := range lines {
+				fmt.Println(line)
+			}
+			if globsFiles {
+				for _, file := range files {
+					if matched[file] {
+						fmt.Printf("      %s\n", file)
+					}
+				}
+			}
+			if len(matched) == 0 {
+				dead = append(dead, r.Path)
+			}
+		}
+
+		if len(dead) > 0 {
+			fmt.Printf("\nDead rules (globs match no file):\n")
+			for _, path := range dead {
+				fmt.Printf("  - %s\n", path)
+			}
+		}
+
+		var uncovered []string
+		for _, file := range files {
+			if !covered[file] {
+				uncovered = append(uncovered, file)
+			}
+		}
+		fmt.Printf("\n%d of %d files are not covered by any rule with globs.\n", len(uncovered), len(files))
+		if globsUncovered {
+			for _, file := range uncovered {
+				fmt.Printf("  - %s\n", file)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(globsCmd)
+	globsCmd.Flags().StringVar(&globsGistID, "gistid", "", "Gist ID of the rule set to check")
+	globsCmd.Flags().StringVar(&globsRevision, "revision", "", "Revision number or version SHA to check")
+	globsCmd.Flags().BoolVar(&globsFiles, "files", false, "List the files each rule attaches to")
+	globsCmd.Flags().BoolVar(&globsUncovered, "uncovered", false, "List the files no rule covers")
+}
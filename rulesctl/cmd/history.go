@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/choigawoon/rulesctl/internal/backend"
+	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyGistID string
+	historyLimit  int
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [title]",
+	Short: "Show revision history of a rule set",
+	Long: `Show every revision of a rule set stored in the backend, newest first.
+For each revision, the version SHA, date, line change counts and the rule files
+added, modified or removed compared to the previous revision are shown.
+
+Revision numbers start at 1 for the first revision and can be used with
+'rulesctl download --revision'.
+
+Examples:
+  rulesctl history "python-linting-rules"
+  rulesctl history --gistid abc123 --limit 5`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := openBackend("", "")
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		targetID, err := resolveRuleset(b, args, historyGistID)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		history, err := b.History(targetID)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("failed to fetch history: %w", err)
+		}
+
+		// History is ordered from newest to oldest
+		count := len(history)
+		if historyLimit > 0 && historyLimit < count {
+			count = historyLimit
+		}
+
+		// Fetch metadata of the shown revisions and the one before the oldest shown
+		metas := make([]*gist.Metadata, len(history))
+		title := ""
+		for i := 0; i < count+1 && i < len(history); i++ {
+			snap, err := b.Get(targetID, history[i].Version)
+			if errors.Is(err, backend.ErrNoMetadata) {
+				// Revisions without rulesctl metadata are shown as such
+				continue
+			}
+			if err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("failed to fetch revision %s: %w", history[i].Version, err)
+			}
+			metas[i] = snap.Meta
+			if title == "" {
+				title = snap.Title
+			}
+		}
+
+		fmt.Printf("History of '%s' (ID: %s, %d revisions)\n\n", title, targetID, len(history))
+
+		for i := 0; i < count; i++ {
+			h := history[i]
+			fmt.Printf("Rev %-4d %s  %s", h.Number, shortVersion(h.Version), h.UpdatedAt.Format("2006-01-02 15:04:05"))
+			// Line counts are only reported by some backends
+			if h.Additions > 0 || h.Deletions > 0 {
+				fmt.Printf("  +%d -%d", h.Additions, h.Deletions)
+			}
+			fmt.Println()
+
+			if metas[i] == nil {
+				fmt.Println("  (no rulesctl metadata)")
+				continue
+			}
+
+			// The first revision is compared against an empty rule set
+			var previous *gist.Metadata
+			if i+1 < len(history) {
+				previous = metas[i+1]
+			}
+
+			changes := gist.CompareMetadata(previous, metas[i])
+			for _, file := range changes.Added {
+				fmt.Printf("  added:    %s\n", file.Path)
+			}
+			for _, file := range changes.Modified {
+				fmt.Printf("  modified: %s\n", file.Path)
+			}
+			for _, file := range changes.Removed {
+				fmt.Printf("  removed:  %s\n", file.Path)
+			}
+			if changes.IsEmpty() {
+				fmt.Println("  (no rule file changes)")
+			}
+		}
+
+		return nil
+	},
+}
+
+// shortVersion returns the abbreviated form of a version SHA.
+func shortVersion(version string) string {
+	if len(version) > 7 {
+		return version[:7]
+	}
+	return version
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().StringVar(&historyGistID, "gistid", "", "Rule set ID to show history of")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 0, "Show only the latest N revisions (0 for all)")
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/choigawoon/rulesctl/internal/archive"
+	"github.com/spf13/cobra"
+)
+
+var importForce bool
+
+var importCmd = &cobra.Command{
+	Use:   "import <archive>",
+	Short: "Install rules from a .tar.gz or .zip archive",
+	Long: `Install the rule set of an archive written by 'rulesctl export' into .cursor/rules.
+Existing files are not overwritten unless --force is given, and every file is
+verified against the MD5 hash in the archive metadata before anything is installed.
+
+Imported rules are not recorded in .cursor/rules/.rulesctl.lock,
+since there is no backend to pull updates from.
+
+Examples:
+  rulesctl import python.zip
+  rulesctl import rules.tar.gz --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		a, err := archive.Open(args[0])
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		fmt.Printf("Importing %d files from %s...\n", len(a.Meta.Files), args[0])
+		if err := installFiles(a.Meta, a.Read, importForce); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		fmt.Println("Import completed successfully.")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().BoolVarP(&importForce, "force", "f", false, "Overwrite existing files")
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/choigawoon/rulesctl/internal/convert"
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importLegacyForce  bool
+	importLegacyDryRun bool
+)
+
+var importLegacyCmd = &cobra.Command{
+	Use:   "import-legacy [file]",
+	Short: "Split a .cursorrules or other single instruction file into .mdc rules",
+	Long: `Split a single instruction file into one .mdc rule per section and write them
+to the rules directory, ready for 'rulesctl upload'.
+
+Without a file, the project root is searched for:
+  ` + strings.Join(convert.LegacyFiles, "\n  ") + `
+
+Sections start at the highest heading level that is used more than once.
+Text before the first section is kept as an overview rule. Every rule is
+written with alwaysApply: true and the heading as its description, so the
+rules behave like the original file; narrow them down with globs afterwards.
+
+Files written by 'rulesctl convert' are skipped when searching.
+Existing .mdc files are not overwritten unless --force is given.
+
+Examples:
+  rulesctl import-legacy
+  rulesctl import-legacy CLAUDE.md --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var src string
+		if len(args) > 0 {
+			src = args[0]
+		} else {
+			found, err := findLegacyFile()
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			src = found
+		}
+
+		content, err := os.ReadFile(src)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("failed to read %s: %w", src, err)
+		}
+		rules := convert.SplitLegacy(filepath.Base(src), content)
+		if len(rules) == 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%s has no content to import", src)
+		}
+
+		rulesDir, err := fileutils.GetRulesDirPath()
+		if err != nil {
+			return err
+		}
+		if !importLegacyForce {
+			var conflicts []string
+			for _, rule := range rules {
+				if _, err := os.Stat(filepath.Join(rulesDir, rule.Path)); err == nil {
+					conflicts = append(conflicts, rule.Path)
+				}
+			}
+			if len(conflicts) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("rule files already exist: %s. Use --force option to overwrite", strings.Join(conflicts, ", "))
+			}
+		}
+
+		fmt.Printf("Splitting %s into %d rules:\n", src, len(rules))
+		for _, rule := range rules {
+			fmt.Printf("  %s (%s)\n", rule.Path, rule.Description)
+		}
+		if importLegacyDryRun {
+			fmt.Println("Dry run: no files were written.")
+			return nil
+		}
+
+		if err := fileutils.EnsureRulesDir(); err != nil {
+			return err
+		}
+		for _, r := range rules {
+			if err := os.WriteFile(filepath.Join(rulesDir, r.Path), r.Bytes(), 0644); err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("failed to write %s: %w", r.Path, err)
+			}
+		}
+
+		fmt.Printf("Wrote %d rules to %s.\n", len(rules), fileutils.DisplayRulesDir())
+		fmt.Printf("Review them, then run 'rulesctl upload' to share them. %s can be removed once the rules are in place.\n", src)
+		return nil
+	},
+}
+
+// findLegacyFile returns the single legacy instruction file in the project root.
+func findLegacyFile() (string, error) {
+	root, err := fileutils.ProjectRoot()
+	if err != nil {
+		return "", err
+	}
+
+	var found []string
+	for _, name := range convert.LegacyFiles {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		// Files rendered from .cursor/rules would only be imported back into it
+		if bytes.Contains(content, []byte(convert.GeneratedMarker)) {
+			continue
+		}
+		found = append(found, fileutils.DisplayPath(path))
+	}
+
+	switch len(found) {
+	case 0:
+		return "", fmt.Errorf("no legacy rule file found (looked for %s)", strings.Join(convert.LegacyFiles, ", "))
+	case 1:
+		return found[0], nil
+	default:
+		return "", fmt.Errorf("found several legacy rule files: %s. Please specify the file to import", strings.Join(found, ", "))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(importLegacyCmd)
+	importLegacyCmd.Flags().BoolVarP(&importLegacyForce, "force", "f", false, "Overwrite existing rule files")
+	importLegacyCmd.Flags().BoolVar(&importLegacyDryRun, "dry-run", false, "Show the rules that would be written without writing them")
+}
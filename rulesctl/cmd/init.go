@@ -5,32 +5,41 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/choigawoon/rulesctl/internal/fileutils"
 	"github.com/spf13/cobra"
 )
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Create default rules directory",
-	Long: `Create .cursor/rules directory in the current directory.
+	Long: `Create .cursor/rules directory in the project root.
 Use --sample flag to create example rule files.
 
+With --dir, the given directory is created instead and saved as "rules_dir"
+in .rulesctl.json, so later commands use it without --dir.
+
 Created files:
 - .cursor/rules/                  : Rules directory
 - .cursor/rules/hello.mdc         : (with --sample) Basic greeting rule`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Check current directory
-		workDir, err := os.Getwd()
+		rulesDir, err := fileutils.GetRulesDirPath()
 		if err != nil {
-			return fmt.Errorf("failed to get working directory: %w", err)
+			return err
 		}
 
-		// Create .cursor/rules directory
-		rulesDir := filepath.Join(workDir, ".cursor", "rules")
+		// Create rules directory
 		if err := os.MkdirAll(rulesDir, 0755); err != nil {
-			return fmt.Errorf("failed to create .cursor/rules directory: %w", err)
+			return fmt.Errorf("failed to create %s directory: %w", fileutils.DisplayPath(rulesDir), err)
 		}
 
-		fmt.Printf(".cursor/rules directory created: %s\n", rulesDir)
+		fmt.Printf("%s directory created: %s\n", fileutils.DisplayPath(rulesDir), rulesDir)
+
+		// Remember a directory given with --dir as the rules directory of the project
+		if fileutils.RulesDirOverridden() {
+			if err := saveProjectRulesDir(rulesDir); err != nil {
+				return err
+			}
+		}
 
 		// Create example files only if --sample flag is used
 		sample, _ := cmd.Flags().GetBool("sample")
@@ -80,6 +89,31 @@ I'll review your code.
 	},
 }
 
+// saveProjectRulesDir saves rulesDir as the rules directory in .rulesctl.json of the project.
+func saveProjectRulesDir(rulesDir string) error {
+	root, err := fileutils.ProjectRoot()
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(root, rulesDir)
+	if err != nil || !filepath.IsLocal(rel) {
+		// A directory outside of the project cannot be configured for it
+		fmt.Printf("%s is outside the project, so it is not saved to %s. Use --dir again in later commands.\n", rulesDir, fileutils.ProjectConfigName)
+		return nil
+	}
+
+	cfg, err := fileutils.LoadProjectConfig(root)
+	if err != nil {
+		return err
+	}
+	cfg.RulesDir = filepath.ToSlash(rel)
+	if err := fileutils.SaveProjectConfig(root, cfg); err != nil {
+		return err
+	}
+	fmt.Printf("Saved rules directory to %s\n", fileutils.DisplayPath(filepath.Join(root, fileutils.ProjectConfigName)))
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(initCmd)
 	initCmd.Flags().Bool("sample", false, "Create example rule files")
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+)
+
+func TestSaveProjectRulesDir(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("작업 디렉토리 확인 실패: %v", err)
+	}
+	defer os.Chdir(wd)
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("작업 디렉토리 변경 실패: %v", err)
+	}
+	root, err := fileutils.ProjectRoot()
+	if err != nil {
+		t.Fatalf("프로젝트 루트 확인 실패: %v", err)
+	}
+
+	// ".."으로 시작하는 이름도 프로젝트 안의 디렉토리
+	if err := saveProjectRulesDir(filepath.Join(root, "..rules")); err != nil {
+		t.Fatalf("saveProjectRulesDir 실패: %v", err)
+	}
+	cfg, err := fileutils.LoadProjectConfig(root)
+	if err != nil || cfg.RulesDir != "..rules" {
+		t.Errorf("rules_dir = %+v, %v; want ..rules", cfg, err)
+	}
+
+	// 프로젝트 밖의 디렉토리는 저장하지 않음
+	if err := saveProjectRulesDir(filepath.Join(filepath.Dir(root), "outside")); err != nil {
+		t.Fatalf("saveProjectRulesDir 실패: %v", err)
+	}
+	if cfg, err = fileutils.LoadProjectConfig(root); err != nil || cfg.RulesDir != "..rules" {
+		t.Errorf("rules_dir = %+v, %v; want ..rules", cfg, err)
+	}
+}
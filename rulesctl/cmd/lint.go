@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintFormat   string
+	lintStrict   bool
+	lintMaxLines int
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check rule files for mistakes before uploading",
+	Long: `Check every .mdc file in .cursor/rules for problems that make Cursor
+ignore or misapply a rule.
+
+Errors:
+  malformed-frontmatter  frontmatter that cannot be read
+  missing-frontmatter    no frontmatter at all
+  duplicate-key          a frontmatter key set more than once
+  invalid-value          alwaysApply that is not true or false
+  invalid-glob           globs with unbalanced brackets or braces, or starting with / or ./
+  empty-body             a rule without content
+
+Warnings:
+  unknown-key            frontmatter keys Cursor does not read
+  never-applied          rules with neither alwaysApply, globs nor a description
+  too-long               rules longer than --max-lines
+  duplicate-description  rules the agent cannot tell apart
+
+Diagnostics are printed as file:line: severity: message [code], or as a JSON
+array with --format json. The command fails if any error is found, or any
+warning with --strict. Use 'rulesctl upload --lint' to lint before uploading.
+
+Examples:
+  rulesctl lint
+  rulesctl lint --format json --strict`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lintFormat != "text" && lintFormat != "json" {
+			return fmt.Errorf("unsupported format: %s (use text or json)", lintFormat)
+		}
+
+		diags, err := lintRules(lint.Options{MaxLines: lintMaxLines})
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		if lintFormat == "json" {
+			if diags == nil {
+				diags = []lint.Diagnostic{}
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(diags); err != nil {
+				return fmt.Errorf("failed to write diagnostics: %w", err)
+			}
+		} else {
+			printDiagnostics(diags)
+		}
+
+		errorCount := lint.Errors(diags)
+		if errorCount > 0 || (lintStrict && len(diags) > 0) {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("lint found %d errors and %d warnings", errorCount, len(diags)-errorCount)
+		}
+		return nil
+	},
+}
+
+// lintRules checks the rule files in the rules directory. Diagnostics refer to files
+// by their path from the working directory, so editors can jump to them.
+func lintRules(opts lint.Options) ([]lint.Diagnostic, error) {
+	rulesDir, err := fileutils.GetRulesDirPath()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(rulesDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s directory does not exist", fileutils.DisplayPath(rulesDir))
+	}
+
+	diags, err := lint.Dir(rulesDir, opts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range diags {
+		diags[i].File = fileutils.DisplayPath(rulesDir) + "/" + diags[i].File
+	}
+	return diags, nil
+}
+
+func printDiagnostics(diags []lint.Diagnostic) {
+	for _, d := range diags {
+		fmt.Println(d)
+	}
+	errorCount := lint.Errors(diags)
+	fmt.Printf("%d errors, %d warnings\n", errorCount, len(diags)-errorCount)
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().StringVar(&lintFormat, "format", "text", "Output format: text or json")
+	lintCmd.Flags().BoolVar(&lintStrict, "strict", false, "Fail on warnings as well as errors")
+	lintCmd.Flags().IntVar(&lintMaxLines, "max-lines", lint.DefaultMaxLines, "Warn about rules longer than this many lines")
+}
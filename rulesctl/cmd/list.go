@@ -8,7 +8,6 @@ import (
 	"time"
 	"unicode/utf8"
 
-	"github.com/choigawoon/rulesctl/internal/gist"
 	"github.com/choigawoon/rulesctl/pkg/config"
 	"github.com/spf13/cobra"
 )
@@ -16,7 +15,7 @@ import (
 const (
 	titleWidth = 25    // Title max width
 	dateWidth  = 19    // Date width
-	idWidth    = 32    // Gist ID width
+	idWidth    = 32    // Rule set ID width
 	revWidth   = 8     // Revision width
 	typeWidth  = 8     // Type width (Public/Private)
 	separator  = "..."
@@ -57,7 +56,7 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List rules stored in GIST or show store list",
 	Long: `List all rules stored in GIST or show store list.
-By default, outputs in [Type] [Title] [Last Modified] [Gist ID] format.
+By default, outputs in [Type] [Title] [Last Modified] [ID] format.
 Use --detail flag to include revision information.
 Use --store flag to show public store list.
 
@@ -73,32 +72,33 @@ Examples:
 			return storeListCmd.RunE(storeListCmd, args)
 		}
 
-		config, err := config.LoadConfig()
+		b, err := openBackend("", "")
 		if err != nil {
-			return fmt.Errorf("failed to load configuration: %w", err)
-		}
-
-		if config.Token == "" {
-			return fmt.Errorf("GitHub token not set. Please run 'rulesctl auth' to set your token")
+			return err
 		}
 
 		// Show token source
-		if os.Getenv("GITHUB_TOKEN") != "" {
-			fmt.Println("GitHub Token: Loaded from environment variable")
-		} else {
-			fmt.Println("GitHub Token: Loaded from config file")
+		if b.Name() == "gist" {
+			if cfg, err := config.LoadConfig(); err == nil && cfg.IsEnterprise() {
+				fmt.Printf("GitHub Enterprise Server: %s\n", cfg.APIHost())
+			}
+			if os.Getenv("GITHUB_TOKEN") != "" {
+				fmt.Println("GitHub Token: Loaded from environment variable")
+			} else {
+				fmt.Println("GitHub Token: Loaded from config file")
+			}
 		}
 
-		// Only fetch Gists from the last month
+		// Only fetch rule sets from the last month
 		since := time.Now().AddDate(0, -1, 0)
-		gists, err := gist.FetchUserGists(&since)
+		rulesets, err := b.List(&since)
 		if err != nil {
-			return fmt.Errorf("failed to fetch Gist list: %w", err)
+			return err
 		}
 
-		// Sort Gists by last modified time
-		sort.Slice(gists, func(i, j int) bool {
-			return gists[i].UpdatedAt.After(gists[j].UpdatedAt)
+		// Sort rule sets by last modified time
+		sort.Slice(rulesets, func(i, j int) bool {
+			return rulesets[i].UpdatedAt.After(rulesets[j].UpdatedAt)
 		})
 
 		// Check detail mode
@@ -108,7 +108,7 @@ Examples:
 		typeHeader := truncateString("Type", typeWidth)
 		titleHeader := truncateString("Title", titleWidth)
 		dateHeader := truncateString("Last Modified", dateWidth)
-		idHeader := truncateString("Gist ID", idWidth)
+		idHeader := truncateString("ID", idWidth)
 		
 		if detail {
 			revHeader := truncateString("Rev", revWidth)
@@ -119,9 +119,9 @@ Examples:
 			fmt.Println(strings.Repeat("-", typeWidth+titleWidth+dateWidth+idWidth+6))
 		}
 
-		// Print each Gist information
-		for _, g := range gists {
-			description := g.Description
+		// Print each rule set information
+		for _, g := range rulesets {
+			description := g.Title
 			if description == "" {
 				description = "(No title)"
 			}
@@ -136,12 +136,12 @@ Examples:
 			id := truncateString(g.ID, idWidth)
 
 			if detail {
-				// Fetch detailed information and history
-				gistDetail, err := gist.FetchGistWithHistory(config.Token, g.ID)
+				// Fetch revision history
+				history, err := b.History(g.ID)
 				if err != nil {
 					continue // Skip if history fetch fails
 				}
-				rev := truncateString(fmt.Sprintf("%d", gistDetail.RevisionNumber), revWidth)
+				rev := truncateString(fmt.Sprintf("%d", len(history)), revWidth)
 				fmt.Printf("%s  %s  %s  %s  %s\n", typeStr, title, date, id, rev)
 			} else {
 				fmt.Printf("%s  %s  %s  %s\n", typeStr, title, date, id)
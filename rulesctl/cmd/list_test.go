@@ -55,6 +55,7 @@ func TestListCmd(t *testing.T) {
 				Language string `json:"language"`
 				RawURL   string `json:"raw_url"`
 				Size     int    `json:"size"`
+				Content  string `json:"content"`
 			}{
 				"test1.mdc": {
 					Filename: "test1.mdc",
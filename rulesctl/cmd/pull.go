@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/choigawoon/rulesctl/internal/lockfile"
+	"github.com/spf13/cobra"
+)
+
+var pullGistID string
+
+var pullCmd = &cobra.Command{
+	Use:   "pull [title]",
+	Short: "Update installed rules, rewriting only changed files",
+	Long: `Incrementally update rules in .cursor/rules from the backend they were installed from.
+Only files whose MD5 hash differs from the remote rule set are downloaded,
+and files removed from the rule set are deleted if they were not modified locally.
+Locally modified files are left untouched unless --force is given.
+
+Without arguments, every rule set recorded in .cursor/rules/.rulesctl.lock is updated,
+along with the rule sets installed into directories configured in .rulesctl.json.
+
+Examples:
+  rulesctl pull                          # Update all installed rule sets
+  rulesctl pull "python-linting-rules"   # Update by title
+  rulesctl pull --gistid abc123          # Update by Gist ID
+  rulesctl pull --force                  # Also overwrite locally modified files`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dirs, err := fileutils.ProjectRulesDirs()
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		// Restore the rules directory given with --dir after switching between directories
+		defer fileutils.SetRulesDir(rulesDirFlag)
+
+		// Each installed rule set is pulled from the backend (and location) it was installed from
+		// into the rules directory it was installed into
+		type target struct {
+			dir      string
+			backend  string
+			location string
+			id       string
+		}
+		var targets []target
+		if len(args) == 0 && pullGistID == "" {
+			for _, dir := range dirs {
+				fileutils.SetRulesDir(dir)
+				lock, err := lockfile.Load()
+				if err != nil {
+					cmd.SilenceUsage = true
+					return err
+				}
+				for _, rs := range lock.Rulesets {
+					targets = append(targets, target{dir, rs.Backend, rs.Location, rs.ID})
+				}
+			}
+			if len(targets) == 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("no installed rule sets found. Use 'rulesctl download' first")
+			}
+		} else {
+			b, err := openBackend("", "")
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			targetID, err := resolveRuleset(b, args, pullGistID)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			// A rule set that is not installed yet goes to its configured directory
+			t := target{backend: b.Name(), id: targetID}
+			for _, dir := range dirs {
+				fileutils.SetRulesDir(dir)
+				lock, err := lockfile.Load()
+				if err != nil {
+					cmd.SilenceUsage = true
+					return err
+				}
+				if rs := lock.Find(t.backend, t.id); rs != nil {
+					t.dir = dir
+					t.location = rs.Location
+					break
+				}
+			}
+			targets = append(targets, t)
+		}
+
+		skipped := false
+		for _, t := range targets {
+			b, err := openBackend(t.backend, t.location)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			snap, err := b.Get(t.id, "")
+			if err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("failed to fetch rule set: %w", err)
+			}
+
+			if t.dir != "" {
+				fileutils.SetRulesDir(t.dir)
+			} else {
+				fileutils.SetRulesDir(rulesDirFlag)
+				if _, err := useRulesetDir(snap.Title); err != nil {
+					cmd.SilenceUsage = true
+					return err
+				}
+			}
+			lock, err := lockfile.Load()
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			var installed []gist.FileMetadata
+			if rs := lock.Find(b.Name(), t.id); rs != nil {
+				installed = rs.Files
+			}
+
+			if len(dirs) > 1 {
+				fmt.Printf("Pulling '%s' (ID: %s) into %s\n", snap.Title, t.id, fileutils.DisplayRulesDir())
+			} else {
+				fmt.Printf("Pulling '%s' (ID: %s)\n", snap.Title, t.id)
+			}
+			result, err := gist.UpdateFiles(snap.Meta, snap.Read, installed, force)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("failed to pull: %w", err)
+			}
+
+			for _, path := range result.Updated {
+				fmt.Printf("  updated: %s\n", path)
+			}
+			for _, path := range result.Deleted {
+				fmt.Printf("  deleted: %s\n", path)
+			}
+			for _, path := range result.Skipped {
+				fmt.Printf("  skipped: %s (modified locally)\n", path)
+			}
+			fmt.Printf("  %d updated, %d deleted, %d unchanged, %d skipped\n",
+				len(result.Updated), len(result.Deleted), len(result.Unchanged), len(result.Skipped))
+			if len(result.Skipped) > 0 {
+				skipped = true
+			}
+
+			lock.Record(lockEntry(b, snap))
+			if err := lock.Save(); err != nil {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("failed to update lock file: %w", err)
+			}
+		}
+
+		if skipped {
+			fmt.Println("Some locally modified files were skipped. Use --force option to overwrite them.")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pullCmd)
+	pullCmd.Flags().StringVar(&pullGistID, "gistid", "", "Rule set ID to pull")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/choigawoon/rulesctl/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Publish rule sets as a static registry",
+	Long: `Publish rule sets as a static registry that can be served by any HTTP server,
+such as an internal static site or a CDN. Consumers read it with the http backend
+and need no API token.
+
+Registry layout:
+  index.json                          rule sets and their versions
+  <id>/<version>/.rulesctl.meta.json  metadata of a version
+  <id>/<version>/<path>               rule files of a version`,
+}
+
+var registryBuildCmd = &cobra.Command{
+	Use:   "build <dir>",
+	Short: "Generate a static registry from the rule sets of a backend",
+	Long: `Generate a static registry in <dir> from every revision of every rule set
+in the selected backend. Running it again updates the registry in place.
+<dir> must be empty or hold a registry built before; only the rule set
+directories listed in its index.json are replaced. A build that fails
+leaves the existing registry untouched.
+
+Examples:
+  rulesctl registry build ./public --backend fs
+  rulesctl registry build /var/www/rules --backend git
+
+Consumers then use the http backend:
+  export RULESCTL_BACKEND=http
+  export RULESCTL_REGISTRY_URL=https://rules.example.com
+  rulesctl download "python-linting-rules"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		b, err := openBackend("", "")
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		fmt.Printf("Building registry from the %s backend...\n", b.Name())
+		index, err := backend.BuildRegistry(b, args[0], func(entry backend.RegistryEntry) {
+			fmt.Printf("  %s (%s): %d versions\n", entry.Title, entry.ID, len(entry.Versions))
+		})
+		if err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("failed to build registry: %w", err)
+		}
+
+		fmt.Printf("Registry written to %s (%d rule sets).\n", args[0], len(index.Rulesets))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(registryCmd)
+	registryCmd.AddCommand(registryBuildCmd)
+}
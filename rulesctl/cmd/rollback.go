@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/backend"
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/spf13/cobra"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback [title]",
+	Short: "Restore a rule set to an earlier revision",
+	Long: `Restore a rule set stored in the backend to an earlier revision.
+The files and metadata of the given revision are published as a new revision,
+and files that did not exist at that revision are deleted.
+Earlier revisions are kept in the rule set history.
+
+Use 'rulesctl history' to find revision numbers.
+
+Examples:
+  rulesctl rollback "my-ruleset" --to 3          # Restore revision 3
+  rulesctl rollback "my-ruleset" --to 3f2a9c1    # Restore by version SHA
+  rulesctl rollback "my-ruleset" --to 3 --force  # Restore without confirmation`,
+	Args:          cobra.ExactArgs(1),
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+		revision, _ := cmd.Flags().GetString("to")
+		title := args[0]
+
+		if revision == "" {
+			return fmt.Errorf("please specify a revision with --to option")
+		}
+
+		cmd.SilenceUsage = true
+		b, err := openBackend("", "")
+		if err != nil {
+			return err
+		}
+
+		targetID, err := resolveRuleset(b, args, "")
+		if err != nil {
+			return err
+		}
+
+		// Fetch the revision to restore
+		snap, err := fetchSnapshot(b, targetID, revision)
+		if err != nil {
+			return fmt.Errorf("failed to fetch revision %s: %w", revision, err)
+		}
+		version, meta := snap.Version, snap.Meta
+
+		// Collect and verify files of the revision
+		files := make(map[string][]byte)
+		for _, file := range meta.Files {
+			content, err := snap.Read(file)
+			if err != nil {
+				return err
+			}
+			if hash := fileutils.CalculateMD5FromBytes(content); hash != file.MD5 {
+				return fmt.Errorf("MD5 hash mismatch (%s): expected %s, got %s", file.Path, file.MD5, hash)
+			}
+			files[file.Path] = content
+		}
+		meta.UpdatedAt = time.Now()
+
+		// Confirm before publishing
+		if !force {
+			fmt.Printf("Restore rule set '%s' to revision %s (%d files)? (y/N): ", title, shortVersion(version), len(meta.Files))
+			var response string
+			fmt.Scanln(&response)
+			if !strings.EqualFold(response, "y") {
+				fmt.Println("Rollback cancelled.")
+				return nil
+			}
+		}
+
+		result, err := b.Put(title, meta, files, backend.PutOptions{Force: true})
+		if err != nil {
+			return err
+		}
+
+		if result.Skipped {
+			fmt.Printf("Rule set '%s' already matches revision %s.\n", title, shortVersion(version))
+			return nil
+		}
+
+		fmt.Printf("Rule set '%s' has been restored to revision %s.\n", title, shortVersion(version))
+		if result.Changes != nil {
+			printChangeSet(result.Changes)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+	rollbackCmd.Flags().String("to", "", "Revision number or version SHA to restore")
+	rollbackCmd.Flags().Bool("force", false, "Restore without confirmation")
+}
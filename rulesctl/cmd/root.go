@@ -4,13 +4,16 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/choigawoon/rulesctl/internal/fileutils"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Global flags
-	verbose bool
-	force   bool
+	verbose      bool
+	force        bool
+	backendName  string
+	rulesDirFlag string
 )
 
 // rootCmd represents the base command
@@ -18,7 +21,27 @@ var rootCmd = &cobra.Command{
 	Use:   "rulesctl",
 	Short: "CLI tool for managing Cursor Rules",
 	Long: `rulesctl is a CLI tool for efficiently managing Cursor Rules.
-You can store and share rule sets through GitHub Gist.`,
+You can store and share rule sets through GitHub Gist.
+
+The storage backend can be selected with the --backend flag,
+the RULESCTL_BACKEND environment variable or "backend" in ~/.rulesctl/config.json.
+Available backends: gist (GitHub Gist, default), fs (local or shared directory),
+git (git repository), gitlab (GitLab snippets), s3 (S3-compatible object storage),
+http (static registry, read-only).
+
+Rules are kept in .cursor/rules in the project root by default. Use the --dir flag
+to work with another directory, or set "rules_dir" in .rulesctl.json in the project root.
+"rulesets" in .rulesctl.json installs individual rule sets into their own directories:
+
+  {
+    "rules_dir": ".cursor/rules",
+    "rulesets": {
+      "backend-rules": "services/api/.cursor/rules"
+    }
+  }`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		fileutils.SetRulesDir(rulesDirFlag)
+	},
 }
 
 // Execute executes the root command
@@ -33,4 +56,6 @@ func init() {
 	// Set global flags
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
 	rootCmd.PersistentFlags().BoolVarP(&force, "force", "f", false, "Force overwrite on conflicts")
+	rootCmd.PersistentFlags().StringVar(&backendName, "backend", "", "Storage backend for rule sets: gist, fs, git, gitlab, s3, http (default: gist, or as configured)")
+	rootCmd.PersistentFlags().StringVar(&rulesDirFlag, "dir", "", "Rules directory to use (default: .cursor/rules, or as configured in .rulesctl.json)")
 } 
\ No newline at end of file
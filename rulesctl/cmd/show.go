@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/choigawoon/rulesctl/internal/rule"
+	"github.com/spf13/cobra"
+)
+
+var (
+	showGistID   string
+	showRevision string
+	showLocal    bool
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show [title]",
+	Short: "Show the rules of a rule set with their types and globs",
+	Long: `Show every rule of a rule set with its type, as Cursor applies it:
+
+  always  alwaysApply: true, included in every request
+  auto    attached when a file matching its globs is referenced
+  agent   included by the agent when the description is relevant
+  manual  only included when referenced explicitly
+
+Examples:
+  rulesctl show "python-linting-rules"
+  rulesctl show --gistid abc123 --revision 2
+  rulesctl show --local   # Show the rules in .cursor/rules`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var rules []*rule.Rule
+		var broken []error
+
+		if showLocal {
+			if len(args) > 0 || showGistID != "" {
+				return fmt.Errorf("--local cannot be combined with a title or --gistid")
+			}
+			rulesDir, err := fileutils.GetRulesDirPath()
+			if err != nil {
+				return err
+			}
+			rules, broken, err = rule.Load(rulesDir)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			fmt.Printf("%s (%d rules)\n", fileutils.DisplayRulesDir(), len(rules))
+		} else {
+			b, err := openBackend("", "")
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			targetID, err := resolveRuleset(b, args, showGistID)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			snap, err := fetchSnapshot(b, targetID, showRevision)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			rules, broken, err = parseRules(snap.Meta, snap.Read)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			fmt.Printf("%s (ID: %s, %d rules)\n", snap.Ruleset.Title, targetID, len(rules))
+		}
+
+		printRules(rules)
+		printBroken(broken)
+		return nil
+	},
+}
+
+// parseRules parses the .mdc files of a rule set. Other files are skipped.
+// Like rule.Load, rules that cannot be parsed are left out and returned in broken;
+// err is only set if a file cannot be read.
+func parseRules(meta *gist.Metadata, read gist.FileReader) (rules []*rule.Rule, broken []error, err error) {
+	for _, file := range meta.Files {
+		if !strings.HasSuffix(file.Path, ".mdc") {
+			continue
+		}
+		content, err := read(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read file %s: %w", file.Path, err)
+		}
+		r, err := rule.Parse(file.Path, content)
+		if err != nil {
+			broken = append(broken, err)
+			continue
+		}
+		rules = append(rules, r)
+	}
+	return rules, broken, nil
+}
+
+// printBroken reports the rule files that could not be parsed and were left out.
+func printBroken(broken []error) {
+	if len(broken) == 0 {
+		return
+	}
+	fmt.Println("Skipped rule files that could not be parsed:")
+	for _, err := range broken {
+		fmt.Printf("  ! %v\n", err)
+	}
+}
+
+// printRules prints one line per rule with its type and globs or description.
+func printRules(rules []*rule.Rule) {
+	pathWidth := 0
+	for _, r := range rules {
+		pathWidth = max(pathWidth, len(r.Path))
+	}
+	for _, r := range rules {
+		detail := ""
+		switch r.Type() {
+		case rule.TypeAuto:
+			detail = strings.Join(r.Globs, ", ")
+		case rule.TypeAgent:
+			detail = r.Description
+		}
+		line := fmt.Sprintf("  - %-*s  %-6s  %s", pathWidth, r.Path, r.Type(), detail)
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+	showCmd.Flags().StringVar(&showGistID, "gistid", "", "Gist ID of the rule set to show")
+	showCmd.Flags().StringVar(&showRevision, "revision", "", "Revision number or version SHA to show")
+	showCmd.Flags().BoolVar(&showLocal, "local", false, "Show the rules in .cursor/rules")
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBrokenRuleDoesNotAbort(t *testing.T) {
+	useMemoryBackend(t)
+
+	rulesDir := filepath.Join(".cursor", "rules")
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		t.Fatalf("룰 디렉토리 생성 실패: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, "good.mdc"), []byte("---\nglobs: *.go\nalwaysApply: true\n---\nbody\n"), 0644); err != nil {
+		t.Fatalf("룰 파일 생성 실패: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, "broken.mdc"), []byte("---\ndescription: x\n"), 0644); err != nil {
+		t.Fatalf("룰 파일 생성 실패: %v", err)
+	}
+
+	showLocal = true
+	defer func() { showLocal = false }()
+	if err := showCmd.RunE(showCmd, nil); err != nil {
+		t.Errorf("show 실패: %v", err)
+	}
+	if err := globsCmd.RunE(globsCmd, nil); err != nil {
+		t.Errorf("globs 실패: %v", err)
+	}
+	if err := statsCmd.RunE(statsCmd, nil); err != nil {
+		t.Errorf("stats 실패: %v", err)
+	}
+
+	convertTargets = []string{"agents"}
+	convertDryRun = true
+	defer func() { convertTargets, convertDryRun = nil, false }()
+	if err := convertCmd.RunE(convertCmd, nil); err != nil {
+		t.Errorf("convert 실패: %v", err)
+	}
+}
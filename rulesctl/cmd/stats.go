@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/rule"
+	"github.com/choigawoon/rulesctl/internal/tokens"
+	"github.com/choigawoon/rulesctl/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsGistID         string
+	statsRevision       string
+	statsBudget         int
+	statsFailOverBudget bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [title]",
+	Short: "Estimate the context size of each rule and check the token budget",
+	Long: `Estimate the number of tokens of every rule and compare the always applied
+rules, which are added to every request, against a token budget.
+
+Tokens are estimated locally from the rule content without a tokenizer
+vocabulary, so expect the numbers to be off by 10-20%.
+
+The budget is taken from --budget, the RULESCTL_TOKEN_BUDGET environment
+variable or token_budget in ~/.rulesctl/config.json, in this order
+(default: ` + fmt.Sprint(config.DefaultTokenBudget) + `).
+
+Without a title, the rules in .cursor/rules are measured. With a title or
+--gistid, the rules of a rule set are measured before downloading it.
+
+Examples:
+  rulesctl stats
+  rulesctl stats --budget 2000 --fail-over-budget
+  rulesctl stats "python-linting-rules"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		budget := statsBudget
+		if !cmd.Flags().Changed("budget") {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+			if budget, err = cfg.Budget(); err != nil {
+				return err
+			}
+		}
+		if budget <= 0 {
+			return fmt.Errorf("budget must be a positive number of tokens")
+		}
+
+		var rules []*rule.Rule
+		var broken []error
+		if len(args) > 0 || statsGistID != "" {
+			b, err := openBackend("", "")
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			targetID, err := resolveRuleset(b, args, statsGistID)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			snap, err := fetchSnapshot(b, targetID, statsRevision)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			if rules, broken, err = parseRules(snap.Meta, snap.Read); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+		} else {
+			rulesDir, err := fileutils.GetRulesDirPath()
+			if err != nil {
+				return err
+			}
+			if rules, broken, err = rule.Load(rulesDir); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+		}
+		printBroken(broken)
+		if len(rules) == 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("no rule files found")
+		}
+
+		pathWidth := len("Rule")
+		for _, r := range rules {
+			pathWidth = max(pathWidth, len(r.Path))
+		}
+
+		totals := make(map[string]int)
+		counts := make(map[string]int)
+		fmt.Printf("%-*s  %-6s  %6s  %7s\n", pathWidth, "Rule", "Type", "Lines", "Tokens")
+		for _, r := range rules {
+			n := tokens.Estimate(r.Body)
+			totals[r.Type()] += n
+			counts[r.Type()]++
+			lines := strings.Count(strings.TrimRight(r.Body, "\n"), "\n") + 1
+			fmt.Printf("%-*s  %-6s  %6d  %7d\n", pathWidth, r.Path, r.Type(), lines, n)
+		}
+
+		fmt.Println()
+		for _, t := range []string{rule.TypeAlways, rule.TypeAuto, rule.TypeAgent, rule.TypeManual} {
+			if counts[t] > 0 {
+				fmt.Printf("%-6s  %3d rules  %7d tokens\n", t, counts[t], totals[t])
+			}
+		}
+
+		always := totals[rule.TypeAlways]
+		fmt.Printf("\nAlways applied: %d of %d tokens (%d%%)\n", always, budget, always*100/budget)
+		if always > budget {
+			fmt.Println("Always applied rules exceed the budget. Consider attaching some of them with globs or a description.")
+			if statsFailOverBudget {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("always applied rules use %d tokens, over the budget of %d", always, budget)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringVar(&statsGistID, "gistid", "", "Gist ID of the rule set to measure")
+	statsCmd.Flags().StringVar(&statsRevision, "revision", "", "Revision number or version SHA to measure")
+	statsCmd.Flags().IntVar(&statsBudget, "budget", config.DefaultTokenBudget, "Token budget of always applied rules")
+	statsCmd.Flags().BoolVar(&statsFailOverBudget, "fail-over-budget", false, "Fail if always applied rules exceed the budget")
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/lockfile"
+	"github.com/spf13/cobra"
+)
+
+var statusAll bool
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show local changes to installed rule sets",
+	Long: `Show the state of files in .cursor/rules compared to the installed rule sets
+recorded in .cursor/rules/.rulesctl.lock. Rules directories configured for
+rule sets in .rulesctl.json are shown one after another.
+
+States:
+  modified   edited locally after download
+  deleted    installed but missing locally
+  untracked  not owned by any installed rule set
+
+Use --all flag to also list unchanged files.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dirs, err := fileutils.ProjectRulesDirs()
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		defer fileutils.SetRulesDir(rulesDirFlag)
+
+		for i, dir := range dirs {
+			fileutils.SetRulesDir(dir)
+			if len(dirs) > 1 {
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("== %s ==\n", fileutils.DisplayPath(dir))
+			}
+			if err := printStatus(); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// printStatus prints the state of the files in the rules directory.
+func printStatus() error {
+	lock, err := lockfile.Load()
+	if err != nil {
+		return err
+	}
+
+	local, err := fileutils.HashRulesDir()
+	if err != nil {
+		return err
+	}
+
+	statuses := lock.Status(local)
+
+	// Print tracked files grouped by rule set
+	for i := range lock.Rulesets {
+		rs := &lock.Rulesets[i]
+		fmt.Printf("Rule set '%s' (%s: %s)\n", rs.Title, rs.Backend, rs.ID)
+		clean := true
+		for _, st := range statuses {
+			if st.Ruleset != rs {
+				continue
+			}
+			if st.State == lockfile.StateUnchanged {
+				if statusAll {
+					fmt.Printf("  %-10s %s\n", st.State+":", st.Path)
+				}
+				continue
+			}
+			clean = false
+			fmt.Printf("  %-10s %s\n", st.State+":", st.Path)
+		}
+		if clean {
+			fmt.Println("  (no local changes)")
+		}
+		fmt.Println()
+	}
+
+	var untracked []string
+	for _, st := range statuses {
+		if st.State == lockfile.StateUntracked {
+			untracked = append(untracked, st.Path)
+		}
+	}
+	if len(untracked) > 0 {
+		fmt.Println("Untracked files:")
+		for _, path := range untracked {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+
+	if len(lock.Rulesets) == 0 && len(untracked) == 0 {
+		fmt.Println("No installed rule sets and no local rule files.")
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().BoolVar(&statusAll, "all", false, "Also show unchanged files")
+}
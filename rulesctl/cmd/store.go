@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/choigawoon/rulesctl/internal/backend"
 	"github.com/choigawoon/rulesctl/internal/fileutils"
 	"github.com/choigawoon/rulesctl/internal/gist"
 	"github.com/choigawoon/rulesctl/pkg/config"
@@ -27,7 +28,10 @@ var storeCmd = &cobra.Command{
 	Use:   "store",
 	Short: "Manage and use public rule store",
 	Long: `Manage and use public rule store.
-The store contains curated cursor rules for various technology stacks.`,
+The store contains curated cursor rules for various technology stacks.
+
+With --registry, a static registry served over HTTP (see 'rulesctl registry build')
+is used as the store instead. No token is needed.`,
 }
 
 // storeListCmd는 'rulesctl store list' 명령어
@@ -35,8 +39,16 @@ var storeListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List available rules in the store",
 	Long: `List all available rules in the public store.
-Shows name, description, category, and full Gist ID for each rule.`,
+Shows name, description, category, and full Gist ID for each rule.
+
+Example:
+  rulesctl store list
+  rulesctl store list --registry https://rules.example.com`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if registryURL, _ := cmd.Flags().GetString("registry"); registryURL != "" {
+			return listRegistry(cmd, backend.NewHTTP(registryURL))
+		}
+
 		// 1. GitHub에서 public-store.json 다운로드
 		const remoteURL = "https://raw.githubusercontent.com/choigawoon/rulesctl/main/public-store.json"
 		
@@ -136,11 +148,20 @@ var storeDownloadCmd = &cobra.Command{
 Finds the Gist ID from the store and downloads it.
 
 Example:
-  rulesctl store download fastapi-patrickjs`,
+  rulesctl store download fastapi-patrickjs
+  rulesctl store download fastapi-patrickjs --revision 2
+  rulesctl store download python-rules --registry https://rules.example.com
+
+Rule sets downloaded from a registry are updated by 'rulesctl pull'
+from the same registry.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
+		if registryURL, _ := cmd.Flags().GetString("registry"); registryURL != "" {
+			return downloadFromRegistry(cmd, backend.NewHTTP(registryURL), name)
+		}
+
 		// 1. public-store.json 읽기
 		configDir, err := config.GetConfigDir()
 		if err != nil {
@@ -195,43 +216,19 @@ Example:
 		// 3. 다운로드 실행 (gist ID로)
 		fmt.Printf("'%s' 룰셋을 다운로드합니다. (Gist ID: %s)\n", targetName, targetGistID)
 
-		// Fetch Gist
-		g, err := gist.FetchGist("", targetGistID) // 공개 Gist는 토큰 필요 없음
+		// Fetch Gist (공개 Gist는 토큰 필요 없음)
+		// 스토어 항목은 항상 github.com의 Gist이므로 설정된 백엔드 및 GitHub Enterprise 설정과 무관하게 사용
+		gist.SetAPIBaseURL(config.DefaultAPIURL)
+		b := backend.NewGist("")
+		revision, _ := cmd.Flags().GetString("revision")
+		snap, err := fetchSnapshot(b, targetGistID, revision)
 		if err != nil {
 			return fmt.Errorf("Gist를 가져오지 못했습니다: %w", err)
 		}
 
-		// Check .rulesctl.meta.json file
-		metaFile, exists := g.Files[gist.MetaFileName]
-		if !exists {
-			return fmt.Errorf("이 Gist는 rulesctl로 관리되지 않습니다 (메타데이터 없음)")
-		}
-
-		// Parse metadata
-		meta, err := gist.ParseMetadataFromGist(metaFile.Content)
-		if err != nil {
-			return fmt.Errorf("메타데이터 파싱 오류: %w", err)
-		}
-
-		// Check for file conflicts
 		forceDownload, _ := cmd.Flags().GetBool("force")
-		if !forceDownload {
-			conflicts, err := gist.CheckConflicts(meta)
-			if err != nil {
-				return fmt.Errorf("충돌 확인 오류: %w", err)
-			}
-			if len(conflicts) > 0 {
-				fmt.Println("다음 파일이 이미 존재합니다:")
-				for _, path := range conflicts {
-					fmt.Printf("  - %s\n", path)
-				}
-				return fmt.Errorf("파일 충돌이 감지되었습니다. --force 옵션을 사용하여 덮어쓰기 가능합니다")
-			}
-		}
-
-		// Download files
-		if err := gist.DownloadFiles("", targetGistID, meta, forceDownload); err != nil {
-			return fmt.Errorf("다운로드 실패: %w", err)
+		if err := installSnapshot(b, snap, forceDownload); err != nil {
+			return err
 		}
 
 		fmt.Println("다운로드가 성공적으로 완료되었습니다.")
@@ -246,4 +243,67 @@ func init() {
 	
 	// 다운로드 시 force 옵션 추가
 	storeDownloadCmd.Flags().Bool("force", false, "Force overwrite if files already exist")
+	storeDownloadCmd.Flags().String("revision", "", "Revision number or version SHA to download")
+
+	storeListCmd.Flags().String("registry", "", "URL of a static registry to use instead of the public store")
+	storeDownloadCmd.Flags().String("registry", "", "URL of a static registry to use instead of the public store")
+}
+
+// listRegistry는 정적 레지스트리의 룰셋 목록을 출력
+func listRegistry(cmd *cobra.Command, b backend.Backend) error {
+	rulesets, err := b.List(nil)
+	if err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("레지스트리 목록을 가져올 수 없습니다: %w", err)
+	}
+	if len(rulesets) == 0 {
+		fmt.Println("레지스트리에 등록된 룰셋이 없습니다.")
+		return nil
+	}
+
+	fmt.Printf("%-*s  %-*s  %s\n", titleWidth, "Title", dateWidth, "Last Modified", "ID")
+	fmt.Println(strings.Repeat("-", titleWidth+dateWidth+4+idWidth))
+	for _, rs := range rulesets {
+		fmt.Printf("%s  %-*s  %s\n", truncateString(rs.Title, titleWidth), dateWidth, rs.UpdatedAt.Local().Format("2006-01-02 15:04:05"), rs.ID)
+	}
+	return nil
+}
+
+// downloadFromRegistry는 정적 레지스트리에서 ID 또는 제목으로 룰셋을 찾아 설치
+func downloadFromRegistry(cmd *cobra.Command, b backend.Backend, name string) error {
+	rulesets, err := b.List(nil)
+	if err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("레지스트리 목록을 가져올 수 없습니다: %w", err)
+	}
+
+	var target *backend.Ruleset
+	for i := range rulesets {
+		if rulesets[i].ID == name || rulesets[i].Title == name {
+			target = &rulesets[i]
+			break
+		}
+	}
+	if target == nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("레지스트리에서 '%s' 항목을 찾을 수 없습니다", name)
+	}
+
+	fmt.Printf("'%s' 룰셋을 다운로드합니다. (ID: %s)\n", target.Title, target.ID)
+
+	revision, _ := cmd.Flags().GetString("revision")
+	snap, err := fetchSnapshot(b, target.ID, revision)
+	if err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("룰셋을 가져오지 못했습니다: %w", err)
+	}
+
+	forceDownload, _ := cmd.Flags().GetBool("force")
+	if err := installSnapshot(b, snap, forceDownload); err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+
+	fmt.Println("다운로드가 성공적으로 완료되었습니다.")
+	return nil
 } 
\ No newline at end of file
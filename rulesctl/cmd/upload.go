@@ -5,37 +5,45 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/choigawoon/rulesctl/internal/backend"
 	"github.com/choigawoon/rulesctl/internal/fileutils"
 	"github.com/choigawoon/rulesctl/internal/gist"
-	"github.com/choigawoon/rulesctl/pkg/config"
+	"github.com/choigawoon/rulesctl/internal/lint"
+	"github.com/choigawoon/rulesctl/internal/rule"
 	"github.com/spf13/cobra"
 )
 
 var (
-	forceUpload bool
-	preview     bool
-	public      bool
+	forceUpload  bool
+	preview      bool
+	public       bool
+	uploadLint   bool
+	uploadBranch string
 )
 
 var uploadCmd = &cobra.Command{
 	Use:   "upload [name]",
 	Short: "Upload local rules to GIST",
-	Long: `Upload rule files from local .cursor/rules directory to GIST.
+	Long: `Upload rule files from local .cursor/rules directory to GIST
+(or the backend selected with --backend).
 The rule set name should be enclosed in quotes.
 
-Use --preview flag to preview metadata without actual upload.
-Use --public flag to create a public gist.`,
+Use --preview flag to preview the rules, with their types and globs, without actual upload.
+Use --public flag to create a public gist.
+Use --lint flag to check the rules with 'rulesctl lint' first; nothing is
+uploaded if an error is found.
+Use --branch flag with the git backend to push the change to a new branch,
+so it can be reviewed and merged through a pull request.
+
+When updating an existing rule set with --force, only changed files are sent,
+files removed locally are deleted from the rule set, and nothing is uploaded
+if no rule file changed.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Load configuration
-		cfg, err := config.LoadConfig()
+		b, err := openBackend("", "")
 		if err != nil {
-			return fmt.Errorf("failed to load configuration: %w", err)
-		}
-
-		if cfg.Token == "" {
 			cmd.SilenceUsage = true
-			return fmt.Errorf("GitHub token not set. Please run 'rulesctl auth' to set your token")
+			return err
 		}
 
 		if len(args) == 0 {
@@ -44,6 +52,10 @@ Use --public flag to create a public gist.`,
 		}
 
 		title := args[0]
+		if uploadBranch != "" && b.Name() != "git" {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("--branch is only supported by the git backend")
+		}
 
 		// Check and create rules directory
 		if err := fileutils.EnsureRulesDir(); err != nil {
@@ -73,17 +85,40 @@ Use --public flag to create a public gist.`,
 			return fmt.Errorf("no rule files to upload")
 		}
 
+		if uploadLint {
+			diags, err := lintRules(lint.Options{})
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			if len(diags) > 0 {
+				printDiagnostics(diags)
+			}
+			if errorCount := lint.Errors(diags); errorCount > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("lint found %d errors; fix them or upload without --lint", errorCount)
+			}
+		}
+
 		// Preview mode only shows metadata
 		if preview {
-			fmt.Printf("Files to upload (total %d):\n", len(meta.Files))
-			for _, file := range meta.Files {
-				fmt.Printf("  - %s\n", file.Path)
+			rulesDir, err := fileutils.GetRulesDirPath()
+			if err != nil {
+				return err
 			}
+			rules, broken, err := rule.Load(rulesDir)
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			fmt.Printf("Files to upload (total %d):\n", len(meta.Files))
+			printRules(rules)
+			printBroken(broken)
 			return nil
 		}
 
-		// Read file contents and create Gist file map
-		files := make(map[string]gist.File)
+		// Read file contents
+		files := make(map[string][]byte)
 		rulesDir, err := fileutils.GetRulesDirPath()
 		if err != nil {
 			return fmt.Errorf("failed to get rules directory path: %v", err)
@@ -95,34 +130,30 @@ Use --public flag to create a public gist.`,
 			if err != nil {
 				return fmt.Errorf("failed to read file %s: %v", fileInfo.Path, err)
 			}
-
-			files[fileInfo.GistName] = gist.File{
-				Content: string(content),
-			}
+			files[fileInfo.Path] = content
 		}
 
-		// Add metadata file
-		metaContent, err := meta.ToJSON()
+		// Create or update rule set
+		result, err := b.Put(title, meta, files, backend.PutOptions{Force: forceUpload, Public: public, Branch: uploadBranch})
 		if err != nil {
-			return fmt.Errorf("failed to generate metadata JSON: %v", err)
-		}
-		files[gist.MetaFileName] = gist.File{
-			Content: string(metaContent),
+			cmd.SilenceUsage = true
+			return err
 		}
 
-		// Initialize Gist client
-		client, err := gist.NewClient()
-		if err != nil {
-			return fmt.Errorf("failed to initialize Gist client: %v", err)
+		if result.Skipped {
+			fmt.Printf("No changes to upload. ID: %s\n", result.ID)
+			return nil
 		}
 
-		// Create or update Gist
-		gistID, err := client.CreateOrUpdateGist(title, files, forceUpload, public)
-		if err != nil {
-			return fmt.Errorf("failed to upload Gist: %v", err)
+		fmt.Printf("Rules successfully uploaded. ID: %s\n", result.ID)
+		if result.Branch != "" {
+			fmt.Printf("Pushed to branch %s. Open a pull request to merge it.\n", result.Branch)
+		}
+		if result.Changes != nil {
+			printChangeSet(result.Changes)
+		} else {
+			fmt.Printf("%d added, %d updated, %d removed\n", len(result.Added), len(result.Updated), len(result.Removed))
 		}
-
-		fmt.Printf("Rules successfully uploaded. Gist ID: %s\n", gistID)
 		return nil
 	},
 }
@@ -132,4 +163,6 @@ func init() {
 	uploadCmd.Flags().BoolVarP(&forceUpload, "force", "f", false, "Force upload when conflicts exist")
 	uploadCmd.Flags().BoolVarP(&preview, "preview", "p", false, "Preview metadata before upload")
 	uploadCmd.Flags().BoolVarP(&public, "public", "", false, "Create a public gist")
+	uploadCmd.Flags().BoolVar(&uploadLint, "lint", false, "Lint the rules and abort the upload on errors")
+	uploadCmd.Flags().StringVar(&uploadBranch, "branch", "", "Push to a new branch for review (git backend, default: git_branch)")
 } 
\ No newline at end of file
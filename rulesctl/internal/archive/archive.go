@@ -0,0 +1,290 @@
+// Package archive writes rule sets to portable .tar.gz and .zip archives and reads them back.
+//
+// An archive contains .rulesctl.meta.json with the MD5 hash of every file,
+// followed by the rule files at their paths relative to the rules directory.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+// maxFileSize limits the size of a single archive entry, so a malicious archive cannot exhaust memory
+const maxFileSize = 32 << 20
+
+// Format is an archive format.
+type Format int
+
+const (
+	TarGz Format = iota // gzip compressed tar archive (.tar.gz, .tgz)
+	Zip                 // zip archive (.zip)
+)
+
+// Archive is a rule set read from an archive.
+type Archive struct {
+	Meta  *gist.Metadata
+	files map[string][]byte
+}
+
+// FormatOf returns the archive format for the extension of path (.tar.gz, .tgz or .zip).
+func FormatOf(path string) (Format, error) {
+	name := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return TarGz, nil
+	case strings.HasSuffix(name, ".zip"):
+		return Zip, nil
+	default:
+		return 0, fmt.Errorf("unsupported archive format: %s (use .tar.gz, .tgz or .zip)", filepath.Base(path))
+	}
+}
+
+// Write writes the files of a rule set to a new archive at path.
+// The format is chosen by the extension of path.
+func Write(path string, meta *gist.Metadata, read gist.FileReader) error {
+	format, err := FormatOf(path)
+	if err != nil {
+		return err
+	}
+
+	metaContent, err := meta.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to generate metadata JSON: %v", err)
+	}
+
+	var buf bytes.Buffer
+	var w entryWriter
+	switch format {
+	case Zip:
+		w = newZipWriter(&buf)
+	default:
+		w = newTarGzWriter(&buf)
+	}
+
+	if err := w.add(gist.MetaFileName, metaContent); err != nil {
+		return err
+	}
+	for _, file := range meta.Files {
+		if !validPath(file.Path) {
+			return fmt.Errorf("invalid file path: %s", file.Path)
+		}
+		content, err := read(file)
+		if err != nil {
+			return fmt.Errorf("failed to read file %s: %w", file.Path, err)
+		}
+		if err := w.add(file.Path, content); err != nil {
+			return err
+		}
+	}
+	if err := w.close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	// Only write the file once the archive is complete
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+// Open reads the archive at path. Every file listed in the metadata must be present;
+// their hashes are verified when the files are installed.
+func Open(path string) (*Archive, error) {
+	format, err := FormatOf(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files map[string][]byte
+	switch format {
+	case Zip:
+		files, err = readZip(path)
+	default:
+		files, err = readTarGz(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	metaContent, exists := files[gist.MetaFileName]
+	if !exists {
+		return nil, fmt.Errorf("not a rulesctl archive: %s not found", gist.MetaFileName)
+	}
+	meta, err := gist.ParseMetadataFromGist(string(metaContent))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range meta.Files {
+		if !validPath(file.Path) {
+			return nil, fmt.Errorf("invalid file path in metadata: %s", file.Path)
+		}
+		if _, exists := files[file.Path]; !exists {
+			return nil, fmt.Errorf("file listed in metadata not found in archive: %s", file.Path)
+		}
+	}
+	return &Archive{Meta: meta, files: files}, nil
+}
+
+// Read returns the content of a file of the archive. It is a gist.FileReader.
+func (a *Archive) Read(file gist.FileMetadata) ([]byte, error) {
+	content, exists := a.files[file.Path]
+	if !exists {
+		return nil, fmt.Errorf("file not found in archive: %s", file.Path)
+	}
+	return content, nil
+}
+
+type entryWriter interface {
+	add(name string, content []byte) error
+	close() error
+}
+
+type tarGzWriter struct {
+	gz  *gzip.Writer
+	tar *tar.Writer
+}
+
+func newTarGzWriter(w io.Writer) *tarGzWriter {
+	gz := gzip.NewWriter(w)
+	return &tarGzWriter{gz: gz, tar: tar.NewWriter(gz)}
+}
+
+func (w *tarGzWriter) add(name string, content []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}
+	if err := w.tar.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := w.tar.Write(content); err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	return nil
+}
+
+func (w *tarGzWriter) close() error {
+	if err := w.tar.Close(); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
+
+type zipWriter struct {
+	zip *zip.Writer
+}
+
+func newZipWriter(w io.Writer) *zipWriter {
+	return &zipWriter{zip: zip.NewWriter(w)}
+}
+
+func (w *zipWriter) add(name string, content []byte) error {
+	header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()}
+	header.SetMode(0644)
+	f, err := w.zip.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := f.Write(content); err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	return nil
+}
+
+func (w *zipWriter) close() error {
+	return w.zip.Close()
+}
+
+func readTarGz(path string) (map[string][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := readEntry(header.Name, tr)
+		if err != nil {
+			return nil, err
+		}
+		files[cleanName(header.Name)] = content
+	}
+}
+
+func readZip(path string) (map[string][]byte, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := readEntry(f.Name, rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		files[cleanName(f.Name)] = content
+	}
+	return files, nil
+}
+
+func readEntry(name string, r io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(content) > maxFileSize {
+		return nil, fmt.Errorf("file too large: %s", name)
+	}
+	return content, nil
+}
+
+// cleanName normalizes an entry name, e.g. "./a.mdc" to "a.mdc".
+func cleanName(name string) string {
+	return path.Clean(strings.TrimPrefix(name, "./"))
+}
+
+// validPath reports whether p is a relative slash separated path that stays inside the rules directory.
+func validPath(p string) bool {
+	return p != "" && !strings.Contains(p, `\`) && filepath.IsLocal(filepath.FromSlash(p)) && path.Clean(p) == p
+}
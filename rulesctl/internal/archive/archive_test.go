@@ -0,0 +1,103 @@
+package archive
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+func testRuleset(contents map[string]string) (*gist.Metadata, gist.FileReader) {
+	meta := &gist.Metadata{}
+	for path, content := range contents {
+		meta.Files = append(meta.Files, gist.FileMetadata{
+			Path:     path,
+			GistName: path,
+			Size:     int64(len(content)),
+			MD5:      fileutils.CalculateMD5FromBytes([]byte(content)),
+		})
+	}
+	return meta, func(file gist.FileMetadata) ([]byte, error) {
+		return []byte(contents[file.Path]), nil
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	contents := map[string]string{"a.mdc": "aaa", "python/lint.mdc": "lint"}
+	meta, read := testRuleset(contents)
+
+	for _, name := range []string{"rules.tar.gz", "rules.tgz", "rules.zip"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := Write(path, meta, read); err != nil {
+				t.Fatalf("Write 실패: %v", err)
+			}
+
+			a, err := Open(path)
+			if err != nil {
+				t.Fatalf("Open 실패: %v", err)
+			}
+			if len(a.Meta.Files) != len(contents) {
+				t.Fatalf("파일 수 = %d; want %d", len(a.Meta.Files), len(contents))
+			}
+			for _, file := range a.Meta.Files {
+				content, err := a.Read(file)
+				if err != nil {
+					t.Fatalf("Read 실패: %v", err)
+				}
+				if string(content) != contents[file.Path] {
+					t.Errorf("%s 내용 = %q; want %q", file.Path, content, contents[file.Path])
+				}
+			}
+		})
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	if _, err := FormatOf("rules.rar"); err == nil {
+		t.Error("지원하지 않는 형식에 대해 에러가 발생해야 합니다")
+	}
+	if format, err := FormatOf("RULES.ZIP"); err != nil || format != Zip {
+		t.Errorf("FormatOf(RULES.ZIP) = %v, %v; want Zip", format, err)
+	}
+}
+
+func TestOpenInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	// writeZip writes a zip archive with the given entries
+	writeZip := func(name string, entries map[string]string) string {
+		path := filepath.Join(dir, name)
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		zw := zip.NewWriter(f)
+		for entry, content := range entries {
+			w, _ := zw.Create(entry)
+			w.Write([]byte(content))
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	if _, err := Open(writeZip("nometa.zip", map[string]string{"a.mdc": "aaa"})); err == nil {
+		t.Error("메타데이터가 없는 아카이브에 대해 에러가 발생해야 합니다")
+	}
+
+	escape := `{"files":[{"path":"../../escape.mdc","gist_name":"x","md5":"x"}]}`
+	if _, err := Open(writeZip("escape.zip", map[string]string{gist.MetaFileName: escape, "../../escape.mdc": "x"})); err == nil {
+		t.Error("규칙 디렉토리 밖의 경로에 대해 에러가 발생해야 합니다")
+	}
+
+	missing := `{"files":[{"path":"a.mdc","gist_name":"a.mdc","md5":"x"}]}`
+	if _, err := Open(writeZip("missing.zip", map[string]string{gist.MetaFileName: missing})); err == nil {
+		t.Error("메타데이터에 있는 파일이 없으면 에러가 발생해야 합니다")
+	}
+}
@@ -0,0 +1,200 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/choigawoon/rulesctl/internal/s3"
+	"github.com/choigawoon/rulesctl/pkg/config"
+)
+
+// DefaultName is the backend used when none is configured
+const DefaultName = "gist"
+
+// ErrNoMetadata is returned by Get when a revision has no rulesctl metadata
+var ErrNoMetadata = errors.New("not managed by rulesctl (no metadata file)")
+
+// Ruleset is a rule set stored in a backend.
+type Ruleset struct {
+	ID        string
+	Title     string
+	Public    bool
+	UpdatedAt time.Time
+}
+
+// Revision is a stored version of a rule set.
+type Revision struct {
+	Version   string // Backend specific version identifier
+	Number    int    // 1 is the first revision
+	UpdatedAt time.Time
+	Additions int // Added lines, if reported by the backend
+	Deletions int // Deleted lines, if reported by the backend
+}
+
+// Snapshot is a rule set at a specific revision.
+type Snapshot struct {
+	Ruleset
+	Version string          // Version of the snapshot ("" if the backend has no versions)
+	Meta    *gist.Metadata  // Rule set metadata
+	Read    gist.FileReader // Reads the content of a file listed in Meta
+}
+
+// PutOptions controls how a rule set is stored.
+type PutOptions struct {
+	Force  bool   // Update the rule set if one with the same title exists
+	Public bool   // Make a newly created rule set public
+	Branch string // Push the change to this new branch for review instead of the default branch (git only)
+}
+
+// PutResult describes the outcome of storing a rule set.
+type PutResult struct {
+	ID      string
+	Added   []string        // Stored file names that did not exist before
+	Updated []string        // Stored file names that were overwritten
+	Removed []string        // Stored file names deleted because they are no longer uploaded
+	Changes *gist.ChangeSet // Rule file changes against the previous revision (nil if unknown)
+	Skipped bool            // Nothing changed, so no new revision was created
+	Branch  string          // Branch the change was pushed to for review (git only)
+}
+
+// Backend stores rule sets.
+type Backend interface {
+	// Name returns the configuration name of the backend
+	Name() string
+	// List returns the stored rule sets, optionally only those updated after since
+	List(since *time.Time) ([]Ruleset, error)
+	// Get returns a rule set at the given version ("" for the latest)
+	Get(id, version string) (*Snapshot, error)
+	// Put creates or updates the rule set with the given title
+	// files maps each FileMetadata.Path of meta to its content
+	Put(title string, meta *gist.Metadata, files map[string][]byte, opts PutOptions) (*PutResult, error)
+	// Delete deletes a rule set
+	Delete(id string) error
+	// History returns the revisions of a rule set, newest first
+	History(id string) ([]Revision, error)
+}
+
+// Locator is implemented by backends that read from a location that is not fully
+// determined by their configuration name, such as the URL of a static registry.
+// The location is recorded with installed rule sets so they are updated from the same place.
+type Locator interface {
+	// Location returns the URL the backend reads from
+	Location() string
+}
+
+// New returns the backend with the given name.
+// An empty name selects the backend configured in cfg, or the default backend.
+func New(name string, cfg *config.Config) (Backend, error) {
+	if name == "" {
+		name = cfg.Backend
+	}
+	if name == "" {
+		name = DefaultName
+	}
+
+	switch name {
+	case "gist":
+		return NewGist(cfg.Token), nil
+	case "fs":
+		root := cfg.FSRoot
+		if root == "" {
+			dir, err := config.GetConfigDir()
+			if err != nil {
+				return nil, err
+			}
+			root = filepath.Join(dir, "registry")
+		}
+		return NewFS(root), nil
+	case "git":
+		if cfg.GitRepo == "" {
+			return nil, fmt.Errorf("git repository not set. Set RULESCTL_GIT_REPO or \"git_repo\" in the configuration")
+		}
+		dir, err := config.GetConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		return NewGit(cfg.GitRepo, filepath.Join(dir, "cache", "git"), cfg.GitBranch), nil
+	case "gitlab":
+		return NewGitLab(cfg.GitLabBaseURL(), cfg.GitLabToken(), cfg.GitLabProject), nil
+	case "s3":
+		if cfg.S3Bucket == "" {
+			return nil, fmt.Errorf("S3 bucket not set. Set RULESCTL_S3_BUCKET or \"s3_bucket\" in the configuration")
+		}
+		accessKeyID, secretAccessKey, sessionToken := cfg.S3Credentials()
+		client := s3.NewClient(cfg.S3EndpointURL(), cfg.S3Bucket, cfg.S3RegionName(), s3.Credentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			SessionToken:    sessionToken,
+		})
+		return NewS3(client, cfg.S3Prefix), nil
+	case "http":
+		if cfg.RegistryURL == "" {
+			return nil, fmt.Errorf("registry URL not set. Set RULESCTL_REGISTRY_URL or \"registry_url\" in the configuration")
+		}
+		return NewHTTP(cfg.RegistryURL), nil
+	default:
+		return nil, fmt.Errorf("unknown backend: %s", name)
+	}
+}
+
+// FindByTitle returns the rule set with the given title.
+func FindByTitle(b Backend, title string) (*Ruleset, error) {
+	rulesets, err := b.List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rs := range rulesets {
+		if rs.Title == title {
+			return &rs, nil
+		}
+	}
+	return nil, fmt.Errorf("rule set not found: %s", title)
+}
+
+// ResolveRevision returns the version of a revision in history.
+// rev is either a revision number (1 is the first revision) or a (prefix of a) version.
+func ResolveRevision(history []Revision, rev string) (string, error) {
+	if rev == "" {
+		return "", fmt.Errorf("revision not specified")
+	}
+	if len(history) == 0 {
+		return "", fmt.Errorf("rule set has no revision history")
+	}
+
+	// Revision number
+	if n, err := strconv.Atoi(rev); err == nil {
+		for _, h := range history {
+			if h.Number == n {
+				return h.Version, nil
+			}
+		}
+		return "", fmt.Errorf("revision %d out of range (1-%d)", n, len(history))
+	}
+
+	// Version or prefix
+	var matched string
+	for _, h := range history {
+		if strings.HasPrefix(h.Version, rev) {
+			if matched != "" {
+				return "", fmt.Errorf("ambiguous revision: %s", rev)
+			}
+			matched = h.Version
+		}
+	}
+	if matched == "" {
+		return "", fmt.Errorf("revision not found: %s", rev)
+	}
+	return matched, nil
+}
+
+// validKey reports whether p is a relative slash separated path without "." or ".." elements.
+func validKey(p string) bool {
+	return p != "" && !strings.HasPrefix(p, "/") && path.Clean(p) == p && p != "." && p != ".." && !strings.HasPrefix(p, "../")
+}
@@ -0,0 +1,155 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/choigawoon/rulesctl/pkg/config"
+)
+
+// testRuleset returns metadata and contents for the given path -> content pairs.
+func testRuleset(contents map[string]string) (*gist.Metadata, map[string][]byte) {
+	meta := &gist.Metadata{}
+	files := make(map[string][]byte)
+	for path, content := range contents {
+		meta.Files = append(meta.Files, gist.FileMetadata{
+			Path:     path,
+			GistName: path,
+			Size:     int64(len(content)),
+			MD5:      fileutils.CalculateMD5FromBytes([]byte(content)),
+		})
+		files[path] = []byte(content)
+	}
+	return meta, files
+}
+
+func TestNew(t *testing.T) {
+	b, err := New("", &config.Config{})
+	if err != nil {
+		t.Fatalf("기본 백엔드 생성 실패: %v", err)
+	}
+	if b.Name() != DefaultName {
+		t.Errorf("기본 백엔드 = %s; want %s", b.Name(), DefaultName)
+	}
+
+	if _, err := New("", &config.Config{Backend: "unknown"}); err == nil {
+		t.Error("알 수 없는 백엔드에 대해 에러가 발생해야 합니다")
+	}
+}
+
+func TestResolveRevision(t *testing.T) {
+	history := []Revision{
+		{Version: "ccc333", Number: 3},
+		{Version: "bbb222", Number: 2},
+		{Version: "bba111", Number: 1},
+	}
+
+	tests := []struct {
+		rev     string
+		want    string
+		wantErr bool
+	}{
+		{rev: "1", want: "bba111"},
+		{rev: "3", want: "ccc333"},
+		{rev: "4", wantErr: true},
+		{rev: "0", wantErr: true},
+		{rev: "ccc", want: "ccc333"},
+		{rev: "bbb222", want: "bbb222"},
+		{rev: "bb", wantErr: true},
+		{rev: "ddd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveRevision(history, tt.rev)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ResolveRevision(%s) 에러: %v, 예상 에러 여부: %v", tt.rev, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveRevision(%s) = %s; want %s", tt.rev, got, tt.want)
+		}
+	}
+
+	if _, err := ResolveRevision(nil, "1"); err == nil {
+		t.Error("히스토리가 없으면 에러가 발생해야 합니다")
+	}
+}
+
+func TestMemoryBackend(t *testing.T) {
+	b := NewMemory()
+
+	meta, files := testRuleset(map[string]string{"a.mdc": "aaa", "b.mdc": "bbb"})
+	result, err := b.Put("test-rules", meta, files, PutOptions{})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if len(result.Changes.Added) != 2 {
+		t.Errorf("추가된 파일 수 = %d; want 2", len(result.Changes.Added))
+	}
+
+	// Existing rule sets are only updated with Force
+	if _, err := b.Put("test-rules", meta, files, PutOptions{}); err == nil {
+		t.Error("Force 없이 기존 룰셋을 덮어쓰면 에러가 발생해야 합니다")
+	}
+
+	// Unchanged rule sets do not create a revision
+	result, err = b.Put("test-rules", meta, files, PutOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if !result.Skipped {
+		t.Error("변경 사항이 없으면 업로드를 건너뛰어야 합니다")
+	}
+
+	meta2, files2 := testRuleset(map[string]string{"a.mdc": "aaa2"})
+	result, err = b.Put("test-rules", meta2, files2, PutOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if len(result.Changes.Modified) != 1 || len(result.Changes.Removed) != 1 {
+		t.Errorf("변경 사항 = %+v; want 1 modified, 1 removed", result.Changes)
+	}
+
+	rs, err := FindByTitle(b, "test-rules")
+	if err != nil {
+		t.Fatalf("FindByTitle 실패: %v", err)
+	}
+	if rs.ID != result.ID {
+		t.Errorf("FindByTitle ID = %s; want %s", rs.ID, result.ID)
+	}
+	if _, err := FindByTitle(b, "missing"); err == nil {
+		t.Error("존재하지 않는 룰셋에 대해 에러가 발생해야 합니다")
+	}
+
+	history, err := b.History(rs.ID)
+	if err != nil {
+		t.Fatalf("History 실패: %v", err)
+	}
+	if len(history) != 2 || history[0].Number != 2 {
+		t.Fatalf("History = %+v; want 2 revisions, newest first", history)
+	}
+
+	// Read the first revision
+	snap, err := b.Get(rs.ID, history[1].Version)
+	if err != nil {
+		t.Fatalf("Get 실패: %v", err)
+	}
+	if len(snap.Meta.Files) != 2 {
+		t.Errorf("첫 리비전 파일 수 = %d; want 2", len(snap.Meta.Files))
+	}
+	content, err := snap.Read(gist.FileMetadata{Path: "b.mdc"})
+	if err != nil {
+		t.Fatalf("Read 실패: %v", err)
+	}
+	if string(content) != "bbb" {
+		t.Errorf("파일 내용 = %q; want %q", content, "bbb")
+	}
+
+	if err := b.Delete(rs.ID); err != nil {
+		t.Fatalf("Delete 실패: %v", err)
+	}
+	if _, err := b.Get(rs.ID, ""); err == nil {
+		t.Error("삭제된 룰셋에 대해 에러가 발생해야 합니다")
+	}
+}
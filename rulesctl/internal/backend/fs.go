@@ -0,0 +1,352 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+// rulesetFileName stores the title and visibility of a rule set in directory based backends
+const rulesetFileName = "ruleset.json"
+
+// FS stores rule sets in a directory, for example a shared network mount.
+//
+// Layout:
+//
+//	<root>/<id>/ruleset.json              title and visibility
+//	<root>/<id>/<n>/.rulesctl.meta.json   metadata of revision n
+//	<root>/<id>/<n>/<path>                rule files of revision n
+//
+// Revisions are immutable numbered snapshots; the latest revision has the highest number.
+type FS struct {
+	root string
+}
+
+// rulesetInfo is the content of rulesetFileName.
+type rulesetInfo struct {
+	Title     string    `json:"title"`
+	Public    bool      `json:"public"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewFS returns a filesystem backend storing rule sets under root.
+func NewFS(root string) *FS {
+	return &FS{root: root}
+}
+
+func (b *FS) Name() string {
+	return "fs"
+}
+
+func (b *FS) List(since *time.Time) ([]Ruleset, error) {
+	entries, err := os.ReadDir(b.root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read registry directory: %w", err)
+	}
+
+	var rulesets []Ruleset
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		rs, err := b.ruleset(entry.Name())
+		if err != nil {
+			// Skip directories that are not rule sets
+			continue
+		}
+		if since != nil && rs.UpdatedAt.Before(*since) {
+			continue
+		}
+		rulesets = append(rulesets, *rs)
+	}
+	return rulesets, nil
+}
+
+func (b *FS) Get(id, version string) (*Snapshot, error) {
+	rs, err := b.ruleset(id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := b.revisions(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("rule set %s has no revisions", id)
+	}
+	if version == "" {
+		version = strconv.Itoa(revisions[len(revisions)-1])
+	} else if n, err := strconv.Atoi(version); err != nil || !slices.Contains(revisions, n) {
+		return nil, fmt.Errorf("revision not found: %s", version)
+	}
+
+	dir := filepath.Join(b.root, id, version)
+	data, err := os.ReadFile(filepath.Join(dir, gist.MetaFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("rule set %s revision %s: %w", id, version, ErrNoMetadata)
+		}
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+	meta, err := gist.ParseMetadataFromGist(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{
+		Ruleset: *rs,
+		Version: version,
+		Meta:    meta,
+		Read: func(file gist.FileMetadata) ([]byte, error) {
+			if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+				return nil, fmt.Errorf("invalid file path in metadata: %s", file.Path)
+			}
+			return os.ReadFile(filepath.Join(dir, filepath.FromSlash(file.Path)))
+		},
+	}, nil
+}
+
+func (b *FS) Put(title string, meta *gist.Metadata, files map[string][]byte, opts PutOptions) (*PutResult, error) {
+	rulesets, err := b.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	var existing *Ruleset
+	for i := range rulesets {
+		if rulesets[i].Title == title {
+			existing = &rulesets[i]
+		}
+	}
+
+	var id string
+	var previous *gist.Metadata
+	if existing != nil {
+		if !opts.Force {
+			return nil, fmt.Errorf("rule set already exists. Use --force option to force update")
+		}
+		id = existing.ID
+		snap, err := b.Get(id, "")
+		if err != nil {
+			return nil, err
+		}
+		previous = snap.Meta
+	}
+
+	changes := gist.CompareMetadata(previous, meta)
+	if existing != nil && changes.IsEmpty() {
+		return &PutResult{ID: id, Changes: changes, Skipped: true}, nil
+	}
+
+	if existing == nil {
+		id, err = b.create(title, opts.Public)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.writeRevision(id, meta, files); err != nil {
+		if existing == nil {
+			// Do not leave a rule set without revisions behind
+			os.RemoveAll(filepath.Join(b.root, id))
+		}
+		return nil, err
+	}
+	return &PutResult{ID: id, Changes: changes}, nil
+}
+
+func (b *FS) Delete(id string) error {
+	if _, err := b.ruleset(id); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(b.root, id)); err != nil {
+		return fmt.Errorf("failed to delete rule set: %w", err)
+	}
+	return nil
+}
+
+func (b *FS) History(id string) ([]Revision, error) {
+	if _, err := b.ruleset(id); err != nil {
+		return nil, err
+	}
+	numbers, err := b.revisions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]Revision, 0, len(numbers))
+	for i := len(numbers) - 1; i >= 0; i-- {
+		rev := Revision{Version: strconv.Itoa(numbers[i]), Number: numbers[i]}
+		if info, err := os.Stat(filepath.Join(b.root, id, rev.Version)); err == nil {
+			rev.UpdatedAt = info.ModTime()
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, nil
+}
+
+// ruleset reads the rule set description stored in the directory of id.
+func (b *FS) ruleset(id string) (*Ruleset, error) {
+	if !filepath.IsLocal(id) || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid rule set ID: %s", id)
+	}
+
+	data, err := os.ReadFile(filepath.Join(b.root, id, rulesetFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("rule set not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to read rule set %s: %w", id, err)
+	}
+
+	var stored rulesetInfo
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse rule set %s: %w", id, err)
+	}
+
+	rs := &Ruleset{ID: id, Title: stored.Title, Public: stored.Public, UpdatedAt: stored.CreatedAt}
+	if revisions, err := b.revisions(id); err == nil && len(revisions) > 0 {
+		latest := strconv.Itoa(revisions[len(revisions)-1])
+		if info, err := os.Stat(filepath.Join(b.root, id, latest)); err == nil {
+			rs.UpdatedAt = info.ModTime()
+		}
+	}
+	return rs, nil
+}
+
+// revisions returns the revision numbers of a rule set in ascending order.
+func (b *FS) revisions(id string) ([]int, error) {
+	entries, err := os.ReadDir(filepath.Join(b.root, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule set %s: %w", id, err)
+	}
+
+	var numbers []int
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if n, err := strconv.Atoi(entry.Name()); err == nil && n > 0 {
+			numbers = append(numbers, n)
+		}
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// create creates the directory of a new rule set and returns its ID.
+// The ID is derived from the title and made unique within the root.
+func (b *FS) create(title string, public bool) (string, error) {
+	if err := os.MkdirAll(b.root, 0755); err != nil {
+		return "", fmt.Errorf("failed to create registry directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(rulesetInfo{Title: title, Public: public, CreatedAt: time.Now()}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	base := slugify(title)
+	for i := 1; ; i++ {
+		id := base
+		if i > 1 {
+			id = fmt.Sprintf("%s-%d", base, i)
+		}
+		// Mkdir fails if the ID is taken, also by a concurrent upload
+		if err := os.Mkdir(filepath.Join(b.root, id), 0755); err != nil {
+			if os.IsExist(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to create rule set directory: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(b.root, id, rulesetFileName), append(data, '\n'), 0644); err != nil {
+			return "", fmt.Errorf("failed to write rule set file: %w", err)
+		}
+		return id, nil
+	}
+}
+
+// writeRevision stores files and metadata as the next numbered revision of a rule set.
+// The revision is written to a temporary directory first, so readers never see a partial revision.
+func (b *FS) writeRevision(id string, meta *gist.Metadata, files map[string][]byte) error {
+	dir := filepath.Join(b.root, id)
+	tmpDir, err := os.MkdirTemp(dir, ".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, file := range meta.Files {
+		content, exists := files[file.Path]
+		if !exists {
+			return fmt.Errorf("missing content for file: %s", file.Path)
+		}
+		path := filepath.FromSlash(file.Path)
+		if !filepath.IsLocal(path) {
+			return fmt.Errorf("invalid file path: %s", file.Path)
+		}
+		if err := os.MkdirAll(filepath.Join(tmpDir, filepath.Dir(path)), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, path), content, 0644); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", file.Path, err)
+		}
+	}
+
+	metaContent, err := meta.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to generate metadata JSON: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, gist.MetaFileName), metaContent, 0644); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+	// MkdirTemp creates the directory with mode 0700
+	if err := os.Chmod(tmpDir, 0755); err != nil {
+		return err
+	}
+
+	revisions, err := b.revisions(id)
+	if err != nil {
+		return err
+	}
+	next := 1
+	if len(revisions) > 0 {
+		next = revisions[len(revisions)-1] + 1
+	}
+
+	// Rename fails if a concurrent upload created the same revision
+	if err := os.Rename(tmpDir, filepath.Join(dir, strconv.Itoa(next))); err != nil {
+		return fmt.Errorf("failed to store revision %d: %w", next, err)
+	}
+	return nil
+}
+
+// slugify turns a title into a directory name.
+// Directory based backends derive rule set IDs from it.
+func slugify(title string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune('-')
+		}
+	}
+	slug := strings.Trim(sb.String(), "-.")
+	if slug == "" {
+		return "ruleset"
+	}
+	return slug
+}
@@ -0,0 +1,123 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+func TestFSBackend(t *testing.T) {
+	root := t.TempDir()
+	b := NewFS(root)
+
+	rulesets, err := b.List(nil)
+	if err != nil {
+		t.Fatalf("빈 저장소 List 실패: %v", err)
+	}
+	if len(rulesets) != 0 {
+		t.Errorf("빈 저장소 룰셋 수 = %d; want 0", len(rulesets))
+	}
+
+	meta, files := testRuleset(map[string]string{"python/lint.mdc": "lint", "b.mdc": "bbb"})
+	result, err := b.Put("Python Rules", meta, files, PutOptions{Public: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if result.ID != "python-rules" {
+		t.Errorf("룰셋 ID = %s; want python-rules", result.ID)
+	}
+
+	// Revisions are stored as plain directories
+	content, err := os.ReadFile(filepath.Join(root, "python-rules", "1", "python", "lint.mdc"))
+	if err != nil || string(content) != "lint" {
+		t.Errorf("리비전 파일 = %q, %v; want %q", content, err, "lint")
+	}
+	if _, err := os.Stat(filepath.Join(root, "python-rules", "1", gist.MetaFileName)); err != nil {
+		t.Errorf("메타데이터 파일이 없습니다: %v", err)
+	}
+
+	if _, err := b.Put("Python Rules", meta, files, PutOptions{}); err == nil {
+		t.Error("Force 없이 기존 룰셋을 덮어쓰면 에러가 발생해야 합니다")
+	}
+	result, err = b.Put("Python Rules", meta, files, PutOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if !result.Skipped {
+		t.Error("변경 사항이 없으면 업로드를 건너뛰어야 합니다")
+	}
+
+	meta2, files2 := testRuleset(map[string]string{"python/lint.mdc": "lint v2"})
+	result, err = b.Put("Python Rules", meta2, files2, PutOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if len(result.Changes.Modified) != 1 || len(result.Changes.Removed) != 1 {
+		t.Errorf("변경 사항 = %+v; want 1 modified, 1 removed", result.Changes)
+	}
+
+	// A different title with the same slug gets its own ID
+	other, err := b.Put("python rules", meta, files, PutOptions{})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if other.ID != "python-rules-2" {
+		t.Errorf("중복 slug 룰셋 ID = %s; want python-rules-2", other.ID)
+	}
+
+	rs, err := FindByTitle(b, "Python Rules")
+	if err != nil {
+		t.Fatalf("FindByTitle 실패: %v", err)
+	}
+	if !rs.Public {
+		t.Error("공개 룰셋이 비공개로 표시됩니다")
+	}
+
+	history, err := b.History(rs.ID)
+	if err != nil {
+		t.Fatalf("History 실패: %v", err)
+	}
+	if len(history) != 2 || history[0].Version != "2" || history[1].Number != 1 {
+		t.Fatalf("History = %+v; want revisions 2, 1", history)
+	}
+
+	snap, err := b.Get(rs.ID, "")
+	if err != nil {
+		t.Fatalf("Get 실패: %v", err)
+	}
+	if snap.Version != "2" || len(snap.Meta.Files) != 1 {
+		t.Errorf("최신 리비전 = %s (%d files); want 2 (1 file)", snap.Version, len(snap.Meta.Files))
+	}
+
+	snap, err = b.Get(rs.ID, "1")
+	if err != nil {
+		t.Fatalf("Get 실패: %v", err)
+	}
+	content, err = snap.Read(gist.FileMetadata{Path: "b.mdc"})
+	if err != nil || string(content) != "bbb" {
+		t.Errorf("첫 리비전 파일 = %q, %v; want %q", content, err, "bbb")
+	}
+	if _, err := snap.Read(gist.FileMetadata{Path: "../ruleset.json"}); err == nil {
+		t.Error("룰셋 디렉토리 밖의 경로를 읽을 수 있습니다")
+	}
+
+	if _, err := b.Get(rs.ID, "3"); err == nil {
+		t.Error("존재하지 않는 리비전에 대해 에러가 발생해야 합니다")
+	}
+	if _, err := b.Get("../other", ""); err == nil {
+		t.Error("잘못된 룰셋 ID에 대해 에러가 발생해야 합니다")
+	}
+
+	if err := b.Delete(rs.ID); err != nil {
+		t.Fatalf("Delete 실패: %v", err)
+	}
+	rulesets, err = b.List(nil)
+	if err != nil {
+		t.Fatalf("List 실패: %v", err)
+	}
+	if len(rulesets) != 1 || rulesets[0].ID != "python-rules-2" {
+		t.Errorf("삭제 후 룰셋 = %+v; want python-rules-2 only", rulesets)
+	}
+}
@@ -0,0 +1,146 @@
+package backend
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+// Gist stores rule sets as GitHub Gists.
+type Gist struct {
+	token string
+}
+
+// NewGist returns a Gist backend. The token may be empty for reading public Gists.
+func NewGist(token string) *Gist {
+	return &Gist{token: token}
+}
+
+func (b *Gist) Name() string {
+	return "gist"
+}
+
+// Location returns the GitHub API base URL the Gists are read from.
+func (b *Gist) Location() string {
+	return gist.APIBaseURL()
+}
+
+func (b *Gist) List(since *time.Time) ([]Ruleset, error) {
+	if b.token == "" {
+		return nil, fmt.Errorf("GitHub token not set. Please run 'rulesctl auth' to set your token")
+	}
+
+	gists, err := gist.FetchUserGists(since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Gist list: %w", err)
+	}
+
+	rulesets := make([]Ruleset, 0, len(gists))
+	for _, g := range gists {
+		rulesets = append(rulesets, Ruleset{
+			ID:        g.ID,
+			Title:     g.Description,
+			Public:    g.Public,
+			UpdatedAt: g.UpdatedAt,
+		})
+	}
+	return rulesets, nil
+}
+
+func (b *Gist) Get(id, version string) (*Snapshot, error) {
+	g, meta, err := gist.FetchRevisionMetadata(b.token, id, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Gist: %w", err)
+	}
+	if meta == nil {
+		return nil, fmt.Errorf("Gist %s: %w", id, ErrNoMetadata)
+	}
+
+	if version == "" && len(g.History) > 0 {
+		version = g.History[0].Version
+	}
+
+	return &Snapshot{
+		Ruleset: Ruleset{
+			ID:        g.ID,
+			Title:     g.Description,
+			Public:    g.Public,
+			UpdatedAt: g.UpdatedAt,
+		},
+		Version: version,
+		Meta:    meta,
+		Read: func(file gist.FileMetadata) ([]byte, error) {
+			content, err := g.FileContent(file.GistName)
+			return []byte(content), err
+		},
+	}, nil
+}
+
+func (b *Gist) Put(title string, meta *gist.Metadata, files map[string][]byte, opts PutOptions) (*PutResult, error) {
+	if b.token == "" {
+		return nil, fmt.Errorf("GitHub token not set. Please run 'rulesctl auth' to set your token")
+	}
+
+	// Gist files are named by GistName
+	gistFiles := make(map[string]gist.File)
+	for _, file := range meta.Files {
+		content, exists := files[file.Path]
+		if !exists {
+			return nil, fmt.Errorf("missing content for file: %s", file.Path)
+		}
+		gistFiles[file.GistName] = gist.File{Content: string(content)}
+	}
+
+	metaContent, err := meta.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate metadata JSON: %v", err)
+	}
+	gistFiles[gist.MetaFileName] = gist.File{Content: string(metaContent)}
+
+	client, err := gist.NewClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Gist client: %v", err)
+	}
+
+	result, err := client.CreateOrUpdateGist(title, gistFiles, opts.Force, opts.Public)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload Gist: %v", err)
+	}
+
+	return &PutResult{
+		ID:      result.GistID,
+		Added:   result.Added,
+		Updated: result.Updated,
+		Removed: result.Removed,
+		Changes: result.Changes,
+		Skipped: result.Skipped,
+	}, nil
+}
+
+func (b *Gist) Delete(id string) error {
+	if err := gist.DeleteGist(id); err != nil {
+		return fmt.Errorf("failed to delete Gist: %w", err)
+	}
+	return nil
+}
+
+func (b *Gist) History(id string) ([]Revision, error) {
+	g, err := gist.FetchGistWithHistory(b.token, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Gist: %w", err)
+	}
+
+	// Gist history is ordered from newest to oldest
+	revisions := make([]Revision, 0, len(g.History))
+	for i, h := range g.History {
+		revisions = append(revisions, Revision{
+			Version:   h.Version,
+			Number:    len(g.History) - i,
+			UpdatedAt: h.UpdatedAt,
+			Additions: h.ChangeStatus.Additions,
+			Deletions: h.ChangeStatus.Deletions,
+		})
+	}
+	return revisions, nil
+}
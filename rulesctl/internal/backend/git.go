@@ -0,0 +1,466 @@
+package backend
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+// Git stores rule sets in a git repository, so changes can go through the usual review process.
+//
+// Layout (at the repository root):
+//
+//	<id>/ruleset.json          title and visibility
+//	<id>/.rulesctl.meta.json   rule set metadata
+//	<id>/<path>                rule files
+//
+// Every upload or deletion is a commit, and revisions of a rule set are the commits touching its directory.
+// Repositories are always used through a private clone in a cache directory, so a local checkout
+// is never modified. Commits are pushed to the default branch, or to a new branch when a branch is
+// set, so the change can be reviewed and merged through a pull request.
+type Git struct {
+	repo     string // Repository path or URL
+	cacheDir string // Directory for private clones
+	branch   string // Branch changes are pushed to for review ("" for the default branch)
+	dir      string // Private clone used for reads and commits
+	worktree bool   // The repository is a local working tree, whose checked-out branch cannot be pushed to
+	synced   bool
+}
+
+// NewGit returns a git backend for the repository at repo (a local path or a URL).
+// The repository is cloned below cacheDir. Changes are pushed to branch, or to the
+// default branch if branch is empty.
+func NewGit(repo, cacheDir, branch string) *Git {
+	return &Git{repo: repo, cacheDir: cacheDir, branch: branch}
+}
+
+func (b *Git) Name() string {
+	return "git"
+}
+
+func (b *Git) List(since *time.Time) ([]Ruleset, error) {
+	if err := b.sync(); err != nil {
+		return nil, err
+	}
+	if !b.hasCommits() {
+		return nil, nil
+	}
+
+	out, err := b.git("ls-tree", "-d", "--name-only", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	var rulesets []Ruleset
+	for _, id := range splitLines(out) {
+		rs, err := b.ruleset(id)
+		if err != nil {
+			// Skip directories that are not rule sets
+			continue
+		}
+		if since != nil && rs.UpdatedAt.Before(*since) {
+			continue
+		}
+		rulesets = append(rulesets, *rs)
+	}
+	return rulesets, nil
+}
+
+func (b *Git) Get(id, version string) (*Snapshot, error) {
+	if err := b.sync(); err != nil {
+		return nil, err
+	}
+	rs, err := b.ruleset(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == "" {
+		version, err = b.git("log", "-1", "--format=%H", "HEAD", "--", id)
+		if err != nil {
+			return nil, err
+		}
+		version = strings.TrimSpace(version)
+	} else if _, err := b.git("cat-file", "-e", version+"^{commit}"); err != nil {
+		return nil, fmt.Errorf("revision not found: %s", version)
+	}
+
+	metaContent, err := b.git("show", version+":"+id+"/"+gist.MetaFileName)
+	if err != nil {
+		return nil, fmt.Errorf("rule set %s revision %s: %w", id, shortSHA(version), ErrNoMetadata)
+	}
+	meta, err := gist.ParseMetadataFromGist(metaContent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{
+		Ruleset: *rs,
+		Version: version,
+		Meta:    meta,
+		Read: func(file gist.FileMetadata) ([]byte, error) {
+			if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+				return nil, fmt.Errorf("invalid file path in metadata: %s", file.Path)
+			}
+			content, err := b.git("show", version+":"+id+"/"+file.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read file %s: %w", file.Path, err)
+			}
+			return []byte(content), nil
+		},
+	}, nil
+}
+
+func (b *Git) Put(title string, meta *gist.Metadata, files map[string][]byte, opts PutOptions) (*PutResult, error) {
+	rulesets, err := b.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	var existing *Ruleset
+	for i := range rulesets {
+		if rulesets[i].Title == title {
+			existing = &rulesets[i]
+		}
+	}
+
+	var id string
+	var previous *gist.Metadata
+	info := rulesetInfo{Title: title, Public: opts.Public, CreatedAt: time.Now()}
+	if existing != nil {
+		if !opts.Force {
+			return nil, fmt.Errorf("rule set already exists. Use --force option to force update")
+		}
+		id = existing.ID
+		snap, err := b.Get(id, "")
+		if err != nil {
+			return nil, err
+		}
+		previous = snap.Meta
+		// Keep title, visibility and creation time of the existing rule set
+		if stored, err := b.rulesetInfo("HEAD", id); err == nil {
+			info = *stored
+		}
+	} else {
+		id = b.newID(title)
+	}
+
+	changes := gist.CompareMetadata(previous, meta)
+	if existing != nil && changes.IsEmpty() {
+		return &PutResult{ID: id, Changes: changes, Skipped: true}, nil
+	}
+
+	branch := opts.Branch
+	if branch == "" {
+		branch = b.branch
+	}
+	if err := b.checkPush(branch); err != nil {
+		return nil, err
+	}
+
+	// Replace the rule set directory with the new revision
+	dir := filepath.Join(b.dir, id)
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("failed to clear rule set directory: %w", err)
+	}
+	for _, file := range meta.Files {
+		content, exists := files[file.Path]
+		if !exists {
+			return nil, fmt.Errorf("missing content for file: %s", file.Path)
+		}
+		path := filepath.FromSlash(file.Path)
+		if !filepath.IsLocal(path) {
+			return nil, fmt.Errorf("invalid file path: %s", file.Path)
+		}
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, path), content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write file %s: %w", file.Path, err)
+		}
+	}
+
+	metaContent, err := meta.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate metadata JSON: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, gist.MetaFileName), metaContent, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write metadata: %w", err)
+	}
+	infoContent, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, rulesetFileName), append(infoContent, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write rule set file: %w", err)
+	}
+
+	action := "Add"
+	if existing != nil {
+		action = "Update"
+	}
+	if err := b.commit(id, fmt.Sprintf("%s rule set %s", action, title), branch); err != nil {
+		return nil, err
+	}
+	return &PutResult{ID: id, Changes: changes, Branch: branch}, nil
+}
+
+func (b *Git) Delete(id string) error {
+	if err := b.sync(); err != nil {
+		return err
+	}
+	rs, err := b.ruleset(id)
+	if err != nil {
+		return err
+	}
+	if err := b.checkPush(b.branch); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(filepath.Join(b.dir, id)); err != nil {
+		return fmt.Errorf("failed to delete rule set: %w", err)
+	}
+	return b.commit(id, fmt.Sprintf("Delete rule set %s", rs.Title), b.branch)
+}
+
+func (b *Git) History(id string) ([]Revision, error) {
+	if err := b.sync(); err != nil {
+		return nil, err
+	}
+	if _, err := b.ruleset(id); err != nil {
+		return nil, err
+	}
+
+	out, err := b.git("log", "--format=%H %cI", "HEAD", "--", id)
+	if err != nil {
+		return nil, err
+	}
+
+	// git log is ordered from newest to oldest
+	lines := splitLines(out)
+	revisions := make([]Revision, 0, len(lines))
+	for i, line := range lines {
+		sha, date, _ := strings.Cut(line, " ")
+		updatedAt, _ := time.Parse(time.RFC3339, date)
+		revisions = append(revisions, Revision{
+			Version:   sha,
+			Number:    len(lines) - i,
+			UpdatedAt: updatedAt,
+		})
+	}
+	return revisions, nil
+}
+
+// sync prepares the working tree, cloning or updating remote repositories once per backend.
+func (b *Git) sync() error {
+	if b.synced {
+		return nil
+	}
+
+	repo := b.repo
+	if info, err := os.Stat(b.repo); err == nil && info.IsDir() {
+		out, err := runGit(b.repo, "rev-parse", "--is-bare-repository")
+		if err != nil {
+			return fmt.Errorf("%s is not a git repository: %w", b.repo, err)
+		}
+		if b.worktree = strings.TrimSpace(out) == "false"; b.worktree {
+			out, err = runGit(b.repo, "rev-parse", "--show-toplevel")
+		} else {
+			out, err = runGit(b.repo, "rev-parse", "--absolute-git-dir")
+		}
+		if err != nil {
+			return err
+		}
+		repo = strings.TrimSpace(out)
+	}
+
+	// Every repository is used through a private clone, never through the user's own checkout
+	sum := sha1.Sum([]byte(repo))
+	b.dir = filepath.Join(b.cacheDir, hex.EncodeToString(sum[:8]))
+
+	if _, err := os.Stat(filepath.Join(b.dir, ".git")); os.IsNotExist(err) {
+		if err := os.MkdirAll(b.cacheDir, 0755); err != nil {
+			return fmt.Errorf("failed to create cache directory: %w", err)
+		}
+		if _, err := runGit("", "clone", "-q", repo, b.dir); err != nil {
+			return fmt.Errorf("failed to clone %s: %w", b.repo, err)
+		}
+	} else {
+		if _, err := b.git("fetch", "-q", "origin"); err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", b.repo, err)
+		}
+		// Discard local state left behind by failed pushes
+		if upstream, err := b.git("rev-parse", "-q", "--verify", "@{upstream}"); err == nil {
+			if _, err := b.git("reset", "-q", "--hard", strings.TrimSpace(upstream)); err != nil {
+				return err
+			}
+		}
+	}
+
+	b.synced = true
+	return nil
+}
+
+// checkPush returns an error if changes cannot be pushed to branch.
+// The checked-out branch of a working tree cannot be pushed to, so it needs a branch for review.
+func (b *Git) checkPush(branch string) error {
+	if branch == "" && b.worktree {
+		return fmt.Errorf("%s is a working tree, so changes must go to a branch for review. Use upload --branch, RULESCTL_GIT_BRANCH or \"git_branch\" in the configuration", b.repo)
+	}
+	return nil
+}
+
+// commit commits the changes in the directory of a rule set and pushes them to the repository,
+// either to its default branch or to a new branch for review.
+func (b *Git) commit(id, message, branch string) error {
+	if _, err := b.git("add", "-A", "--", id); err != nil {
+		return fmt.Errorf("failed to stage rule set: %w", err)
+	}
+
+	// Fall back to a generic identity when git is not configured
+	var args []string
+	if name, _ := b.git("config", "user.name"); strings.TrimSpace(name) == "" {
+		args = append(args, "-c", "user.name=rulesctl")
+	}
+	if email, _ := b.git("config", "user.email"); strings.TrimSpace(email) == "" {
+		args = append(args, "-c", "user.email=rulesctl@localhost")
+	}
+	args = append(args, "commit", "-q", "-m", message, "--", id)
+	if _, err := b.git(args...); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	if branch != "" {
+		// The review branch is not merged yet, so the clone is discarded to read the default branch again
+		defer b.discard()
+		if _, err := b.git("push", "-q", "origin", "HEAD:refs/heads/"+branch); err != nil {
+			return fmt.Errorf("failed to push branch %s to %s (the branch may already exist): %w", branch, b.repo, err)
+		}
+		return nil
+	}
+
+	if _, err := b.git("push", "-q", "-u", "origin", "HEAD"); err != nil {
+		// Next sync resets to the remote state
+		b.synced = false
+		return fmt.Errorf("failed to push to %s (the repository may have changed, please retry): %w", b.repo, err)
+	}
+	return nil
+}
+
+// discard removes the private clone, so the next sync clones the repository again.
+func (b *Git) discard() {
+	os.RemoveAll(b.dir)
+	b.synced = false
+}
+
+// ruleset reads the rule set description stored in the directory of id at HEAD.
+func (b *Git) ruleset(id string) (*Ruleset, error) {
+	if !filepath.IsLocal(id) || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid rule set ID: %s", id)
+	}
+	if !b.hasCommits() {
+		return nil, fmt.Errorf("rule set not found: %s", id)
+	}
+
+	info, err := b.rulesetInfo("HEAD", id)
+	if err != nil {
+		return nil, fmt.Errorf("rule set not found: %s", id)
+	}
+
+	rs := &Ruleset{ID: id, Title: info.Title, Public: info.Public, UpdatedAt: info.CreatedAt}
+	if date, err := b.git("log", "-1", "--format=%cI", "HEAD", "--", id); err == nil {
+		if updatedAt, err := time.Parse(time.RFC3339, strings.TrimSpace(date)); err == nil {
+			rs.UpdatedAt = updatedAt
+		}
+	}
+	return rs, nil
+}
+
+// rulesetInfo reads the rule set file of id at the given revision.
+func (b *Git) rulesetInfo(rev, id string) (*rulesetInfo, error) {
+	data, err := b.git("show", rev+":"+id+"/"+rulesetFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var info rulesetInfo
+	if err := json.Unmarshal([]byte(data), &info); err != nil {
+		return nil, fmt.Errorf("failed to parse rule set %s: %w", id, err)
+	}
+	return &info, nil
+}
+
+// newID returns an unused rule set ID derived from the title.
+func (b *Git) newID(title string) string {
+	base := slugify(title)
+	id := base
+	for i := 2; ; i++ {
+		if _, err := os.Stat(filepath.Join(b.dir, id)); os.IsNotExist(err) {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+func (b *Git) hasCommits() bool {
+	_, err := b.git("rev-parse", "-q", "--verify", "HEAD")
+	return err == nil
+}
+
+func (b *Git) git(args ...string) (string, error) {
+	return runGit(b.dir, args...)
+}
+
+// runGit runs git in dir and returns its standard output.
+func runGit(dir string, args ...string) (string, error) {
+	// Name of the git subcommand for error messages
+	name := args[0]
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-c" {
+			i++
+			continue
+		}
+		name = args[i]
+		break
+	}
+
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	cmd := exec.Command("git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", name, msg)
+		}
+		return "", fmt.Errorf("git %s: %w", name, err)
+	}
+	return stdout.String(), nil
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,174 @@
+package backend
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+// initTestRepo creates an empty git repository and returns its path.
+func initTestRepo(t *testing.T, bare bool) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git이 설치되어 있지 않습니다")
+	}
+
+	dir := t.TempDir()
+	args := []string{"init", "-q"}
+	if bare {
+		args = append(args, "--bare")
+	}
+	if _, err := runGit(dir, args...); err != nil {
+		t.Fatalf("저장소 생성 실패: %v", err)
+	}
+	return dir
+}
+
+func TestGitBackend(t *testing.T) {
+	origin := initTestRepo(t, true)
+	b := NewGit(origin, t.TempDir(), "")
+
+	rulesets, err := b.List(nil)
+	if err != nil {
+		t.Fatalf("빈 저장소 List 실패: %v", err)
+	}
+	if len(rulesets) != 0 {
+		t.Errorf("빈 저장소 룰셋 수 = %d; want 0", len(rulesets))
+	}
+
+	meta, files := testRuleset(map[string]string{"python/lint.mdc": "lint", "b.mdc": "bbb"})
+	result, err := b.Put("Python Rules", meta, files, PutOptions{})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if result.ID != "python-rules" {
+		t.Errorf("룰셋 ID = %s; want python-rules", result.ID)
+	}
+
+	// Uploads are pushed to the origin repository
+	content, err := runGit(origin, "show", "HEAD:python-rules/python/lint.mdc")
+	if err != nil || content != "lint" {
+		t.Errorf("origin 파일 = %q, %v; want %q", content, err, "lint")
+	}
+	if _, err := runGit(origin, "show", "HEAD:python-rules/"+gist.MetaFileName); err != nil {
+		t.Errorf("origin에 메타데이터 파일이 없습니다: %v", err)
+	}
+
+	result, err = b.Put("Python Rules", meta, files, PutOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if !result.Skipped {
+		t.Error("변경 사항이 없으면 커밋을 건너뛰어야 합니다")
+	}
+
+	meta2, files2 := testRuleset(map[string]string{"python/lint.mdc": "lint v2"})
+	if _, err := b.Put("Python Rules", meta2, files2, PutOptions{}); err == nil {
+		t.Error("Force 없이 기존 룰셋을 덮어쓰면 에러가 발생해야 합니다")
+	}
+	result, err = b.Put("Python Rules", meta2, files2, PutOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if len(result.Changes.Modified) != 1 || len(result.Changes.Removed) != 1 {
+		t.Errorf("변경 사항 = %+v; want 1 modified, 1 removed", result.Changes)
+	}
+
+	// A new backend sees the pushed commits through its own clone
+	b = NewGit(origin, t.TempDir(), "")
+	history, err := b.History("python-rules")
+	if err != nil {
+		t.Fatalf("History 실패: %v", err)
+	}
+	if len(history) != 2 || history[0].Number != 2 {
+		t.Fatalf("History = %+v; want 2 revisions, newest first", history)
+	}
+	head, _ := runGit(origin, "rev-parse", "HEAD")
+	if history[0].Version != strings.TrimSpace(head) {
+		t.Errorf("최신 리비전 = %s; want %s", history[0].Version, head)
+	}
+
+	snap, err := b.Get("python-rules", history[1].Version)
+	if err != nil {
+		t.Fatalf("Get 실패: %v", err)
+	}
+	if len(snap.Meta.Files) != 2 {
+		t.Errorf("첫 리비전 파일 수 = %d; want 2", len(snap.Meta.Files))
+	}
+	data, err := snap.Read(gist.FileMetadata{Path: "b.mdc"})
+	if err != nil || string(data) != "bbb" {
+		t.Errorf("첫 리비전 파일 = %q, %v; want %q", data, err, "bbb")
+	}
+
+	snap, err = b.Get("python-rules", "")
+	if err != nil {
+		t.Fatalf("Get 실패: %v", err)
+	}
+	if snap.Version != history[0].Version || snap.Title != "Python Rules" {
+		t.Errorf("최신 스냅샷 = %s (%s); want %s", snap.Version, snap.Title, history[0].Version)
+	}
+
+	if err := b.Delete("python-rules"); err != nil {
+		t.Fatalf("Delete 실패: %v", err)
+	}
+	if _, err := runGit(origin, "show", "HEAD:python-rules/"+rulesetFileName); err == nil {
+		t.Error("삭제가 origin에 반영되지 않았습니다")
+	}
+	rulesets, err = b.List(nil)
+	if err != nil {
+		t.Fatalf("List 실패: %v", err)
+	}
+	if len(rulesets) != 0 {
+		t.Errorf("삭제 후 룰셋 수 = %d; want 0", len(rulesets))
+	}
+}
+
+func TestGitBackendWorkingTree(t *testing.T) {
+	repo := initTestRepo(t, false)
+	if _, err := runGit(repo, "-c", "user.name=test", "-c", "user.email=test@localhost", "commit", "-q", "--allow-empty", "-m", "initial"); err != nil {
+		t.Fatalf("초기 커밋 실패: %v", err)
+	}
+	b := NewGit(repo, t.TempDir(), "")
+
+	meta, files := testRuleset(map[string]string{"a.mdc": "aaa"})
+	if _, err := b.Put("team rules", meta, files, PutOptions{}); err == nil {
+		t.Error("작업 트리의 체크아웃된 브랜치에 푸시하면 에러가 발생해야 합니다")
+	}
+	result, err := b.Put("team rules", meta, files, PutOptions{Branch: "rules/team"})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if result.Branch != "rules/team" {
+		t.Errorf("브랜치 = %q; want rules/team", result.Branch)
+	}
+
+	// Changes go to the review branch through a private clone
+	if _, err := runGit(repo, "show", "rules/team:"+filepath.ToSlash(filepath.Join(result.ID, "a.mdc"))); err != nil {
+		t.Errorf("리뷰 브랜치에 룰 파일이 없습니다: %v", err)
+	}
+	out, err := runGit(repo, "log", "--format=%s")
+	if err != nil {
+		t.Fatalf("git log 실패: %v", err)
+	}
+	if strings.TrimSpace(out) != "initial" {
+		t.Errorf("체크아웃된 브랜치가 변경되었습니다: %q", out)
+	}
+	if status, _ := runGit(repo, "status", "--porcelain"); status != "" {
+		t.Errorf("작업 트리가 변경되었습니다: %q", status)
+	}
+
+	// The rule set is not on the default branch until the branch is merged
+	rulesets, err := NewGit(repo, t.TempDir(), "").List(nil)
+	if err != nil {
+		t.Fatalf("List 실패: %v", err)
+	}
+	if len(rulesets) != 0 {
+		t.Errorf("병합 전 룰셋 수 = %d; want 0", len(rulesets))
+	}
+	if rulesets, err = b.List(nil); err != nil || len(rulesets) != 0 {
+		t.Errorf("푸시 후 같은 백엔드의 룰셋 = %v, %v; want 없음", rulesets, err)
+	}
+}
@@ -0,0 +1,348 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+// GitLab stores rule sets as multi-file GitLab snippets, either personal snippets
+// or snippets of a project.
+//
+// GitLab does not expose the commit history of snippets through its API,
+// so only the latest revision of a rule set is available.
+type GitLab struct {
+	apiURL  string // e.g. https://gitlab.com/api/v4
+	token   string
+	project string // Project ID or path for project snippets ("" for personal snippets)
+}
+
+type gitlabSnippet struct {
+	ID         int                 `json:"id"`
+	Title      string              `json:"title"`
+	Visibility string              `json:"visibility"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+	Files      []gitlabSnippetFile `json:"files"`
+}
+
+type gitlabSnippetFile struct {
+	Path   string `json:"path"`
+	RawURL string `json:"raw_url"`
+}
+
+type gitlabFile struct {
+	Action   string `json:"action,omitempty"`
+	FilePath string `json:"file_path"`
+	Content  string `json:"content,omitempty"`
+}
+
+// NewGitLab returns a GitLab snippets backend for the GitLab instance at baseURL (e.g. https://gitlab.com).
+// If project is set, project snippets are used instead of personal snippets.
+func NewGitLab(baseURL, token, project string) *GitLab {
+	return &GitLab{
+		apiURL:  strings.TrimSuffix(baseURL, "/") + "/api/v4",
+		token:   token,
+		project: project,
+	}
+}
+
+func (b *GitLab) Name() string {
+	return "gitlab"
+}
+
+func (b *GitLab) List(since *time.Time) ([]Ruleset, error) {
+	if b.token == "" {
+		return nil, fmt.Errorf("GitLab token not set. Please run 'rulesctl auth --gitlab' or set GITLAB_TOKEN")
+	}
+
+	var rulesets []Ruleset
+	for page := 1; page > 0; {
+		var snippets []gitlabSnippet
+		next, err := b.request("GET", fmt.Sprintf("%s?per_page=100&page=%d", b.snippetsPath(), page), nil, &snippets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch snippet list: %w", err)
+		}
+
+		for _, s := range snippets {
+			// Only snippets managed by rulesctl
+			if s.file(gist.MetaFileName) == nil {
+				continue
+			}
+			if since != nil && s.UpdatedAt.Before(*since) {
+				continue
+			}
+			rulesets = append(rulesets, s.ruleset())
+		}
+		page = next
+	}
+	return rulesets, nil
+}
+
+func (b *GitLab) Get(id, version string) (*Snapshot, error) {
+	s, err := b.snippet(id)
+	if err != nil {
+		return nil, err
+	}
+
+	metaFile := s.file(gist.MetaFileName)
+	if metaFile == nil {
+		return nil, fmt.Errorf("snippet %s: %w", id, ErrNoMetadata)
+	}
+	if version == "" {
+		version = s.ref()
+	}
+
+	data, err := b.raw(id, version, gist.MetaFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metadata: %w", err)
+	}
+	meta, err := gist.ParseMetadataFromGist(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{
+		Ruleset: s.ruleset(),
+		Version: version,
+		Meta:    meta,
+		Read: func(file gist.FileMetadata) ([]byte, error) {
+			return b.raw(id, version, file.GistName)
+		},
+	}, nil
+}
+
+func (b *GitLab) Put(title string, meta *gist.Metadata, files map[string][]byte, opts PutOptions) (*PutResult, error) {
+	rulesets, err := b.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	var existing *Ruleset
+	for i := range rulesets {
+		if rulesets[i].Title == title {
+			existing = &rulesets[i]
+		}
+	}
+
+	metaContent, err := meta.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate metadata JSON: %v", err)
+	}
+
+	if existing == nil {
+		// Create a new snippet with every file
+		snippetFiles := []gitlabFile{{FilePath: gist.MetaFileName, Content: string(metaContent)}}
+		for _, file := range meta.Files {
+			content, exists := files[file.Path]
+			if !exists {
+				return nil, fmt.Errorf("missing content for file: %s", file.Path)
+			}
+			snippetFiles = append(snippetFiles, gitlabFile{FilePath: file.GistName, Content: string(content)})
+		}
+
+		visibility := "private"
+		if opts.Public {
+			visibility = "public"
+		}
+		body := map[string]interface{}{
+			"title":      title,
+			"visibility": visibility,
+			"files":      snippetFiles,
+		}
+
+		var created gitlabSnippet
+		if _, err := b.request("POST", b.snippetsPath(), body, &created); err != nil {
+			return nil, fmt.Errorf("failed to create snippet: %w", err)
+		}
+		return &PutResult{ID: strconv.Itoa(created.ID), Changes: gist.CompareMetadata(nil, meta)}, nil
+	}
+
+	if !opts.Force {
+		return nil, fmt.Errorf("rule set already exists. Use --force option to force update")
+	}
+
+	snap, err := b.Get(existing.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	changes := gist.CompareMetadata(snap.Meta, meta)
+	if changes.IsEmpty() {
+		return &PutResult{ID: existing.ID, Changes: changes, Skipped: true}, nil
+	}
+
+	s, err := b.snippet(existing.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send only changed files, and delete files that are no longer part of the rule set
+	previous := make(map[string]string)
+	for _, file := range snap.Meta.Files {
+		previous[file.GistName] = file.MD5
+	}
+	snippetFiles := []gitlabFile{{Action: "update", FilePath: gist.MetaFileName, Content: string(metaContent)}}
+	uploaded := map[string]bool{gist.MetaFileName: true}
+	for _, file := range meta.Files {
+		uploaded[file.GistName] = true
+		if md5, exists := previous[file.GistName]; exists && md5 == file.MD5 && s.file(file.GistName) != nil {
+			continue
+		}
+		content, exists := files[file.Path]
+		if !exists {
+			return nil, fmt.Errorf("missing content for file: %s", file.Path)
+		}
+		action := "create"
+		if s.file(file.GistName) != nil {
+			action = "update"
+		}
+		snippetFiles = append(snippetFiles, gitlabFile{Action: action, FilePath: file.GistName, Content: string(content)})
+	}
+	for _, file := range s.Files {
+		if !uploaded[file.Path] {
+			snippetFiles = append(snippetFiles, gitlabFile{Action: "delete", FilePath: file.Path})
+		}
+	}
+
+	body := map[string]interface{}{"files": snippetFiles}
+	if _, err := b.request("PUT", b.snippetsPath()+"/"+url.PathEscape(existing.ID), body, nil); err != nil {
+		return nil, fmt.Errorf("failed to update snippet: %w", err)
+	}
+	return &PutResult{ID: existing.ID, Changes: changes}, nil
+}
+
+func (b *GitLab) Delete(id string) error {
+	if _, err := b.request("DELETE", b.snippetsPath()+"/"+url.PathEscape(id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete snippet: %w", err)
+	}
+	return nil
+}
+
+func (b *GitLab) History(id string) ([]Revision, error) {
+	s, err := b.snippet(id)
+	if err != nil {
+		return nil, err
+	}
+	return []Revision{{Version: s.ref(), Number: 1, UpdatedAt: s.UpdatedAt}}, nil
+}
+
+// snippetsPath returns the API path of personal or project snippets.
+func (b *GitLab) snippetsPath() string {
+	if b.project != "" {
+		return "/projects/" + url.PathEscape(b.project) + "/snippets"
+	}
+	return "/snippets"
+}
+
+func (b *GitLab) snippet(id string) (*gitlabSnippet, error) {
+	var s gitlabSnippet
+	if _, err := b.request("GET", b.snippetsPath()+"/"+url.PathEscape(id), nil, &s); err != nil {
+		return nil, fmt.Errorf("failed to fetch snippet: %w", err)
+	}
+	return &s, nil
+}
+
+// raw fetches the content of a snippet file at the given ref.
+func (b *GitLab) raw(id, ref, path string) ([]byte, error) {
+	var data []byte
+	apiPath := fmt.Sprintf("%s/%s/files/%s/%s/raw", b.snippetsPath(), url.PathEscape(id), url.PathEscape(ref), url.PathEscape(path))
+	if _, err := b.request("GET", apiPath, nil, &data); err != nil {
+		return nil, fmt.Errorf("failed to download file %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// request calls the GitLab API and decodes the JSON response into out.
+// If out is a *[]byte, the raw response body is stored instead.
+// Returns the next page number of paginated responses (0 on the last page).
+func (b *GitLab) request(method, path string, body, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, b.apiURL+path, reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if b.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", b.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to make API request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message interface{} `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Message != nil {
+			return 0, fmt.Errorf("API request failed: %s (%v)", resp.Status, apiErr.Message)
+		}
+		return 0, fmt.Errorf("API request failed: %s", resp.Status)
+	}
+
+	switch out := out.(type) {
+	case nil:
+	case *[]byte:
+		if *out, err = io.ReadAll(resp.Body); err != nil {
+			return 0, err
+		}
+	default:
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return 0, fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	next, _ := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	return next, nil
+}
+
+func (s *gitlabSnippet) ruleset() Ruleset {
+	return Ruleset{
+		ID:        strconv.Itoa(s.ID),
+		Title:     s.Title,
+		Public:    s.Visibility == "public",
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func (s *gitlabSnippet) file(path string) *gitlabSnippetFile {
+	for i := range s.Files {
+		if s.Files[i].Path == path {
+			return &s.Files[i]
+		}
+	}
+	return nil
+}
+
+// ref returns the branch of the snippet repository, taken from the file raw URLs
+// (".../raw/<ref>/<path>"). Defaults to "main".
+func (s *gitlabSnippet) ref() string {
+	for _, file := range s.Files {
+		prefix, found := strings.CutSuffix(file.RawURL, "/"+file.Path)
+		if !found {
+			continue
+		}
+		if i := strings.LastIndex(prefix, "/raw/"); i >= 0 && !strings.Contains(prefix[i+len("/raw/"):], "/") {
+			return prefix[i+len("/raw/"):]
+		}
+	}
+	return "main"
+}
@@ -0,0 +1,250 @@
+package backend
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+// fakeGitLab serves the snippets API of a GitLab instance below prefix.
+type fakeGitLab struct {
+	t        *testing.T
+	prefix   string
+	mu       sync.Mutex
+	nextID   int
+	snippets map[int]*fakeSnippet
+}
+
+type fakeSnippet struct {
+	title      string
+	visibility string
+	files      map[string]string
+}
+
+func newFakeGitLab(t *testing.T, prefix string) *httptest.Server {
+	f := &fakeGitLab{t: t, prefix: prefix, snippets: make(map[int]*fakeSnippet)}
+	return httptest.NewServer(f)
+}
+
+func (f *fakeGitLab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("PRIVATE-TOKEN") != "gl-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	path, found := strings.CutPrefix(r.URL.EscapedPath(), f.prefix)
+	if !found {
+		f.t.Errorf("예상하지 못한 경로: %s", r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	if parts[0] == "" {
+		switch r.Method {
+		case "GET":
+			var list []map[string]interface{}
+			for id := 1; id <= f.nextID; id++ {
+				if s, exists := f.snippets[id]; exists {
+					list = append(list, f.snippetJSON(id, s))
+				}
+			}
+			json.NewEncoder(w).Encode(list)
+		case "POST":
+			var body struct {
+				Title      string       `json:"title"`
+				Visibility string       `json:"visibility"`
+				Files      []gitlabFile `json:"files"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			f.nextID++
+			s := &fakeSnippet{title: body.Title, visibility: body.Visibility, files: make(map[string]string)}
+			for _, file := range body.Files {
+				s.files[file.FilePath] = file.Content
+			}
+			f.snippets[f.nextID] = s
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(f.snippetJSON(f.nextID, s))
+		}
+		return
+	}
+
+	id, _ := strconv.Atoi(parts[0])
+	s, exists := f.snippets[id]
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"404 Snippet Not Found"}`))
+		return
+	}
+
+	// files/<ref>/<path>/raw
+	if len(parts) == 5 && parts[1] == "files" && parts[4] == "raw" {
+		content, exists := s.files[strings.ReplaceAll(parts[3], "%2F", "/")]
+		if parts[2] != "main" || !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(content))
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		json.NewEncoder(w).Encode(f.snippetJSON(id, s))
+	case "PUT":
+		var body struct {
+			Files []gitlabFile `json:"files"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		for _, file := range body.Files {
+			_, exists := s.files[file.FilePath]
+			switch file.Action {
+			case "create", "update":
+				if exists != (file.Action == "update") {
+					f.t.Errorf("잘못된 action %s: %s", file.Action, file.FilePath)
+				}
+				s.files[file.FilePath] = file.Content
+			case "delete":
+				delete(s.files, file.FilePath)
+			}
+		}
+		json.NewEncoder(w).Encode(f.snippetJSON(id, s))
+	case "DELETE":
+		delete(f.snippets, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeGitLab) snippetJSON(id int, s *fakeSnippet) map[string]interface{} {
+	var files []map[string]string
+	for path := range s.files {
+		files = append(files, map[string]string{
+			"path":    path,
+			"raw_url": "https://gitlab.example.com/-/snippets/" + strconv.Itoa(id) + "/raw/main/" + path,
+		})
+	}
+	return map[string]interface{}{
+		"id":         id,
+		"title":      s.title,
+		"visibility": s.visibility,
+		"updated_at": time.Now().Format(time.RFC3339),
+		"files":      files,
+	}
+}
+
+func TestGitLabBackend(t *testing.T) {
+	server := newFakeGitLab(t, "/api/v4/snippets")
+	defer server.Close()
+	b := NewGitLab(server.URL+"/", "gl-token", "")
+
+	meta, files := testRuleset(map[string]string{"python/lint.mdc": "lint", "b.mdc": "bbb"})
+	for i := range meta.Files {
+		meta.Files[i].GistName = strings.ReplaceAll(meta.Files[i].Path, "/", "_")
+	}
+	result, err := b.Put("Python Rules", meta, files, PutOptions{Public: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if result.ID != "1" || len(result.Changes.Added) != 2 {
+		t.Errorf("Put 결과 = %+v; want ID 1, 2 added", result)
+	}
+
+	if _, err := b.Put("Python Rules", meta, files, PutOptions{}); err == nil {
+		t.Error("Force 없이 기존 룰셋을 덮어쓰면 에러가 발생해야 합니다")
+	}
+	result, err = b.Put("Python Rules", meta, files, PutOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if !result.Skipped {
+		t.Error("변경 사항이 없으면 업로드를 건너뛰어야 합니다")
+	}
+
+	meta2, files2 := testRuleset(map[string]string{"python/lint.mdc": "lint v2", "c.mdc": "ccc"})
+	for i := range meta2.Files {
+		meta2.Files[i].GistName = strings.ReplaceAll(meta2.Files[i].Path, "/", "_")
+	}
+	result, err = b.Put("Python Rules", meta2, files2, PutOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if len(result.Changes.Added) != 1 || len(result.Changes.Modified) != 1 || len(result.Changes.Removed) != 1 {
+		t.Errorf("변경 사항 = %+v; want 1 added, 1 modified, 1 removed", result.Changes)
+	}
+
+	rulesets, err := b.List(nil)
+	if err != nil {
+		t.Fatalf("List 실패: %v", err)
+	}
+	if len(rulesets) != 1 || rulesets[0].Title != "Python Rules" || !rulesets[0].Public {
+		t.Fatalf("List = %+v; want 1 public rule set", rulesets)
+	}
+
+	snap, err := b.Get("1", "")
+	if err != nil {
+		t.Fatalf("Get 실패: %v", err)
+	}
+	if snap.Version != "main" || len(snap.Meta.Files) != 2 {
+		t.Errorf("스냅샷 = %s (%d files); want main (2 files)", snap.Version, len(snap.Meta.Files))
+	}
+	for _, file := range snap.Meta.Files {
+		content, err := snap.Read(file)
+		if err != nil {
+			t.Fatalf("Read 실패: %v", err)
+		}
+		if string(content) != string(files2[file.Path]) {
+			t.Errorf("%s 내용 = %q; want %q", file.Path, content, files2[file.Path])
+		}
+	}
+
+	history, err := b.History("1")
+	if err != nil {
+		t.Fatalf("History 실패: %v", err)
+	}
+	if len(history) != 1 || history[0].Version != "main" {
+		t.Errorf("History = %+v; want only the latest revision", history)
+	}
+
+	if err := b.Delete("1"); err != nil {
+		t.Fatalf("Delete 실패: %v", err)
+	}
+	if _, err := b.Get("1", ""); err == nil {
+		t.Error("삭제된 룰셋에 대해 에러가 발생해야 합니다")
+	}
+
+	if _, err := NewGitLab(server.URL, "", "").List(nil); err == nil {
+		t.Error("토큰이 없으면 에러가 발생해야 합니다")
+	}
+}
+
+func TestGitLabProjectSnippets(t *testing.T) {
+	server := newFakeGitLab(t, "/api/v4/projects/group%2Fapp/snippets")
+	defer server.Close()
+	b := NewGitLab(server.URL, "gl-token", "group/app")
+
+	meta, files := testRuleset(map[string]string{"a.mdc": "aaa"})
+	if _, err := b.Put("team rules", meta, files, PutOptions{}); err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	rs, err := FindByTitle(b, "team rules")
+	if err != nil {
+		t.Fatalf("FindByTitle 실패: %v", err)
+	}
+	snap, err := b.Get(rs.ID, "")
+	if err != nil {
+		t.Fatalf("Get 실패: %v", err)
+	}
+	content, err := snap.Read(gist.FileMetadata{Path: "a.mdc", GistName: "a.mdc"})
+	if err != nil || string(content) != "aaa" {
+		t.Errorf("파일 내용 = %q, %v; want %q", content, err, "aaa")
+	}
+}
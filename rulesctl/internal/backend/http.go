@@ -0,0 +1,210 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+const (
+	// RegistryIndexFile is the name of the index of a static registry
+	RegistryIndexFile = "index.json"
+
+	// RegistryFormat is the layout version of static registries written by BuildRegistry
+	RegistryFormat = 1
+)
+
+// RegistryIndex is the content of the index of a static registry.
+type RegistryIndex struct {
+	Format      int             `json:"format"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Rulesets    []RegistryEntry `json:"rulesets"`
+}
+
+// RegistryEntry describes a rule set in the index of a static registry.
+type RegistryEntry struct {
+	ID        string            `json:"id"`
+	Title     string            `json:"title"`
+	Public    bool              `json:"public"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	Versions  []RegistryVersion `json:"versions"` // Newest first
+}
+
+// RegistryVersion describes a published revision of a rule set.
+type RegistryVersion struct {
+	Version   string    `json:"version"` // Directory name of the revision
+	Number    int       `json:"number"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// HTTP reads rule sets from a static registry served by any HTTP server, without authentication.
+// It is read-only; registries are published with BuildRegistry.
+//
+// Layout:
+//
+//	<url>/index.json                            rule sets and their versions
+//	<url>/<id>/<version>/.rulesctl.meta.json    metadata of a version
+//	<url>/<id>/<version>/<path>                 rule files of a version
+type HTTP struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTP returns a backend reading the static registry at baseURL.
+func NewHTTP(baseURL string) *HTTP {
+	return &HTTP{baseURL: strings.TrimSuffix(baseURL, "/"), client: http.DefaultClient}
+}
+
+func (b *HTTP) Name() string {
+	return "http"
+}
+
+// Location returns the URL of the registry.
+func (b *HTTP) Location() string {
+	return b.baseURL
+}
+
+func (b *HTTP) List(since *time.Time) ([]Ruleset, error) {
+	index, err := b.index()
+	if err != nil {
+		return nil, err
+	}
+
+	var rulesets []Ruleset
+	for _, entry := range index.Rulesets {
+		if since != nil && entry.UpdatedAt.Before(*since) {
+			continue
+		}
+		rulesets = append(rulesets, entry.ruleset())
+	}
+	return rulesets, nil
+}
+
+func (b *HTTP) Get(id, version string) (*Snapshot, error) {
+	entry, err := b.entry(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(entry.Versions) == 0 {
+		return nil, fmt.Errorf("rule set %s has no revisions", id)
+	}
+
+	rev := &entry.Versions[0]
+	if version != "" {
+		rev = nil
+		for i := range entry.Versions {
+			if entry.Versions[i].Version == version {
+				rev = &entry.Versions[i]
+			}
+		}
+		if rev == nil {
+			return nil, fmt.Errorf("revision not found: %s", version)
+		}
+	}
+
+	data, err := b.fetch(id, rev.Version, gist.MetaFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metadata: %w", err)
+	}
+	meta, err := gist.ParseMetadataFromGist(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	rs := entry.ruleset()
+	rs.UpdatedAt = rev.UpdatedAt
+	return &Snapshot{
+		Ruleset: rs,
+		Version: rev.Version,
+		Meta:    meta,
+		Read: func(file gist.FileMetadata) ([]byte, error) {
+			if !validKey(file.Path) {
+				return nil, fmt.Errorf("invalid file path in metadata: %s", file.Path)
+			}
+			return b.fetch(id, rev.Version, file.Path)
+		},
+	}, nil
+}
+
+func (b *HTTP) Put(title string, meta *gist.Metadata, files map[string][]byte, opts PutOptions) (*PutResult, error) {
+	return nil, fmt.Errorf("the http backend is read-only. Publish rule sets with 'rulesctl registry build'")
+}
+
+func (b *HTTP) Delete(id string) error {
+	return fmt.Errorf("the http backend is read-only. Publish rule sets with 'rulesctl registry build'")
+}
+
+func (b *HTTP) History(id string) ([]Revision, error) {
+	entry, err := b.entry(id)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]Revision, 0, len(entry.Versions))
+	for _, v := range entry.Versions {
+		revisions = append(revisions, Revision{Version: v.Version, Number: v.Number, UpdatedAt: v.UpdatedAt})
+	}
+	return revisions, nil
+}
+
+// index fetches and parses the registry index.
+func (b *HTTP) index() (*RegistryIndex, error) {
+	data, err := b.get(b.baseURL + "/" + RegistryIndexFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch registry index: %w", err)
+	}
+
+	var index RegistryIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse registry index: %w", err)
+	}
+	if index.Format > RegistryFormat {
+		return nil, fmt.Errorf("unsupported registry format %d. Please update rulesctl", index.Format)
+	}
+	return &index, nil
+}
+
+func (b *HTTP) entry(id string) (*RegistryEntry, error) {
+	index, err := b.index()
+	if err != nil {
+		return nil, err
+	}
+	for i := range index.Rulesets {
+		if index.Rulesets[i].ID == id {
+			return &index.Rulesets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("rule set not found: %s", id)
+}
+
+// fetch downloads a file of a rule set version.
+func (b *HTTP) fetch(id, version, path string) ([]byte, error) {
+	segments := []string{url.PathEscape(id), url.PathEscape(version)}
+	for _, segment := range strings.Split(path, "/") {
+		segments = append(segments, url.PathEscape(segment))
+	}
+	return b.get(b.baseURL + "/" + strings.Join(segments, "/"))
+}
+
+func (b *HTTP) get(rawURL string) ([]byte, error) {
+	resp, err := b.client.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (e *RegistryEntry) ruleset() Ruleset {
+	return Ruleset{ID: e.ID, Title: e.Title, Public: e.Public, UpdatedAt: e.UpdatedAt}
+}
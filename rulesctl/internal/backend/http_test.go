@@ -0,0 +1,185 @@
+package backend
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistryRoundTrip(t *testing.T) {
+	src := NewMemory()
+	meta, files := testRuleset(map[string]string{"python/lint.mdc": "lint", "b.mdc": "bbb"})
+	result, err := src.Put("Python Rules", meta, files, PutOptions{Public: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	id := result.ID
+	meta2, files2 := testRuleset(map[string]string{"python/lint.mdc": "lint v2"})
+	if _, err := src.Put("Python Rules", meta2, files2, PutOptions{Force: true}); err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+
+	dir := t.TempDir()
+	var built []string
+	index, err := BuildRegistry(src, dir, func(entry RegistryEntry) {
+		built = append(built, entry.ID)
+	})
+	if err != nil {
+		t.Fatalf("BuildRegistry 실패: %v", err)
+	}
+	if len(index.Rulesets) != 1 || len(index.Rulesets[0].Versions) != 2 || len(built) != 1 {
+		t.Fatalf("인덱스 = %+v; want 1 rule set with 2 versions", index)
+	}
+	if _, err := os.Stat(filepath.Join(dir, id, "1", "python", "lint.mdc")); err != nil {
+		t.Errorf("리비전 1 파일이 없습니다: %v", err)
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+	b := NewHTTP(server.URL + "/")
+
+	rs, err := FindByTitle(b, "Python Rules")
+	if err != nil || rs.ID != id || !rs.Public {
+		t.Fatalf("FindByTitle = %+v, %v; want public rule set %s", rs, err, id)
+	}
+
+	history, err := b.History(id)
+	if err != nil {
+		t.Fatalf("History 실패: %v", err)
+	}
+	if len(history) != 2 || history[0].Number != 2 || history[0].Version != "2" {
+		t.Fatalf("History = %+v; want 2 revisions, newest first", history)
+	}
+
+	for _, tt := range []struct {
+		version string
+		want    map[string][]byte
+	}{
+		{version: "", want: files2},
+		{version: "1", want: files},
+	} {
+		snap, err := b.Get(id, tt.version)
+		if err != nil {
+			t.Fatalf("Get(%q) 실패: %v", tt.version, err)
+		}
+		if len(snap.Meta.Files) != len(tt.want) {
+			t.Errorf("Get(%q) 파일 수 = %d; want %d", tt.version, len(snap.Meta.Files), len(tt.want))
+		}
+		for _, file := range snap.Meta.Files {
+			content, err := snap.Read(file)
+			if err != nil {
+				t.Fatalf("Read(%s) 실패: %v", file.Path, err)
+			}
+			if string(content) != string(tt.want[file.Path]) {
+				t.Errorf("Get(%q) %s 내용 = %q; want %q", tt.version, file.Path, content, tt.want[file.Path])
+			}
+		}
+	}
+	if _, err := b.Get(id, "3"); err == nil {
+		t.Error("존재하지 않는 리비전에 대해 에러가 발생해야 합니다")
+	}
+
+	if _, err := b.Put("Python Rules", meta, files, PutOptions{Force: true}); err == nil {
+		t.Error("http 백엔드는 읽기 전용이어야 합니다")
+	}
+	if err := b.Delete(id); err == nil {
+		t.Error("http 백엔드는 읽기 전용이어야 합니다")
+	}
+
+	// Rebuilding removes rule sets that no longer exist
+	if err := src.Delete(id); err != nil {
+		t.Fatalf("Delete 실패: %v", err)
+	}
+	if _, err := BuildRegistry(src, dir, nil); err != nil {
+		t.Fatalf("BuildRegistry 실패: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, id)); !os.IsNotExist(err) {
+		t.Errorf("삭제된 룰셋 디렉토리가 남아 있습니다: %v", err)
+	}
+	if rulesets, err := b.List(nil); err != nil || len(rulesets) != 0 {
+		t.Errorf("List = %+v, %v; want none", rulesets, err)
+	}
+}
+
+func TestBuildRegistryKeepsUnrelatedFiles(t *testing.T) {
+	src := NewMemory()
+	meta, files := testRuleset(map[string]string{"a.mdc": "aaa"})
+	result, err := src.Put("Rules", meta, files, PutOptions{})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+
+	// 인덱스가 없는 비어 있지 않은 디렉토리는 거부
+	webRoot := t.TempDir()
+	unrelated := filepath.Join(webRoot, result.ID, "index.html")
+	os.MkdirAll(filepath.Dir(unrelated), 0755)
+	if err := os.WriteFile(unrelated, []byte("site"), 0644); err != nil {
+		t.Fatalf("파일 생성 실패: %v", err)
+	}
+	if _, err := BuildRegistry(src, webRoot, nil); err == nil {
+		t.Error("인덱스가 없는 비어 있지 않은 디렉토리에서 에러가 발생해야 합니다")
+	}
+	if _, err := os.Stat(unrelated); err != nil {
+		t.Errorf("관련 없는 파일이 삭제되었습니다: %v", err)
+	}
+
+	// 기존 레지스트리에 나열되지 않은 디렉토리는 삭제하지 않음
+	dir := t.TempDir()
+	if _, err := BuildRegistry(NewMemory(), dir, nil); err != nil {
+		t.Fatalf("BuildRegistry 실패: %v", err)
+	}
+	unrelated = filepath.Join(dir, result.ID, "keep.txt")
+	os.MkdirAll(filepath.Dir(unrelated), 0755)
+	if err := os.WriteFile(unrelated, []byte("keep"), 0644); err != nil {
+		t.Fatalf("파일 생성 실패: %v", err)
+	}
+	if _, err := BuildRegistry(src, dir, nil); err == nil {
+		t.Error("레지스트리에 없는 디렉토리와 겹치면 에러가 발생해야 합니다")
+	}
+	if _, err := os.Stat(unrelated); err != nil {
+		t.Errorf("관련 없는 파일이 삭제되었습니다: %v", err)
+	}
+}
+
+// failingBackend fails to read any revision of its rule sets.
+type failingBackend struct {
+	Backend
+}
+
+func (b failingBackend) Get(id, version string) (*Snapshot, error) {
+	return nil, errors.New("network error")
+}
+
+func TestBuildRegistryFailureKeepsRegistry(t *testing.T) {
+	src := NewMemory()
+	meta, files := testRuleset(map[string]string{"a.mdc": "aaa"})
+	result, err := src.Put("Rules", meta, files, PutOptions{})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "public")
+	if _, err := BuildRegistry(src, dir, nil); err != nil {
+		t.Fatalf("BuildRegistry 실패: %v", err)
+	}
+	index, err := os.ReadFile(filepath.Join(dir, RegistryIndexFile))
+	if err != nil {
+		t.Fatalf("인덱스 읽기 실패: %v", err)
+	}
+
+	if _, err := BuildRegistry(failingBackend{src}, dir, nil); err == nil {
+		t.Fatal("리비전을 읽지 못하면 에러가 발생해야 합니다")
+	}
+	if _, err := os.Stat(filepath.Join(dir, result.ID, "1", "a.mdc")); err != nil {
+		t.Errorf("빌드 실패 후 게시된 룰셋이 사라졌습니다: %v", err)
+	}
+	if after, _ := os.ReadFile(filepath.Join(dir, RegistryIndexFile)); string(after) != string(index) {
+		t.Error("빌드 실패 후 인덱스가 변경되었습니다")
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(dir)); len(entries) != 1 {
+		t.Errorf("임시 빌드 디렉토리가 남아 있습니다: %v", entries)
+	}
+}
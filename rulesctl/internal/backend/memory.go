@@ -0,0 +1,174 @@
+package backend
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+// Memory stores rule sets in memory. It is intended for tests.
+type Memory struct {
+	rulesets map[string]*memoryRuleset
+	nextID   int
+}
+
+type memoryRuleset struct {
+	Ruleset
+	revisions []memoryRevision // oldest first
+}
+
+type memoryRevision struct {
+	Revision
+	meta  []byte
+	files map[string][]byte
+}
+
+// NewMemory returns an empty in-memory backend.
+func NewMemory() *Memory {
+	return &Memory{rulesets: make(map[string]*memoryRuleset)}
+}
+
+func (b *Memory) Name() string {
+	return "memory"
+}
+
+func (b *Memory) List(since *time.Time) ([]Ruleset, error) {
+	var rulesets []Ruleset
+	for _, rs := range b.rulesets {
+		if since != nil && rs.UpdatedAt.Before(*since) {
+			continue
+		}
+		rulesets = append(rulesets, rs.Ruleset)
+	}
+	sort.Slice(rulesets, func(i, j int) bool {
+		return rulesets[i].ID < rulesets[j].ID
+	})
+	return rulesets, nil
+}
+
+func (b *Memory) Get(id, version string) (*Snapshot, error) {
+	rs, exists := b.rulesets[id]
+	if !exists {
+		return nil, fmt.Errorf("rule set not found: %s", id)
+	}
+
+	rev := &rs.revisions[len(rs.revisions)-1]
+	if version != "" {
+		rev = nil
+		for i := range rs.revisions {
+			if rs.revisions[i].Version == version {
+				rev = &rs.revisions[i]
+			}
+		}
+		if rev == nil {
+			return nil, fmt.Errorf("revision not found: %s", version)
+		}
+	}
+
+	meta, err := gist.ParseMetadataFromGist(string(rev.meta))
+	if err != nil {
+		return nil, err
+	}
+
+	files := rev.files
+	return &Snapshot{
+		Ruleset: rs.Ruleset,
+		Version: rev.Version,
+		Meta:    meta,
+		Read: func(file gist.FileMetadata) ([]byte, error) {
+			content, exists := files[file.Path]
+			if !exists {
+				return nil, fmt.Errorf("file not found in rule set: %s", file.Path)
+			}
+			return content, nil
+		},
+	}, nil
+}
+
+func (b *Memory) Put(title string, meta *gist.Metadata, files map[string][]byte, opts PutOptions) (*PutResult, error) {
+	var rs *memoryRuleset
+	for _, existing := range b.rulesets {
+		if existing.Title == title {
+			rs = existing
+		}
+	}
+
+	var previous *gist.Metadata
+	if rs != nil {
+		if !opts.Force {
+			return nil, fmt.Errorf("rule set already exists. Use --force option to force update")
+		}
+		latest := rs.revisions[len(rs.revisions)-1]
+		prev, err := gist.ParseMetadataFromGist(string(latest.meta))
+		if err != nil {
+			return nil, err
+		}
+		previous = prev
+	}
+
+	changes := gist.CompareMetadata(previous, meta)
+	if rs != nil && changes.IsEmpty() {
+		return &PutResult{ID: rs.ID, Changes: changes, Skipped: true}, nil
+	}
+
+	metaContent, err := meta.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string][]byte)
+	for _, file := range meta.Files {
+		content, exists := files[file.Path]
+		if !exists {
+			return nil, fmt.Errorf("missing content for file: %s", file.Path)
+		}
+		stored[file.Path] = append([]byte(nil), content...)
+	}
+
+	if rs == nil {
+		b.nextID++
+		rs = &memoryRuleset{Ruleset: Ruleset{
+			ID:     fmt.Sprintf("mem-%d", b.nextID),
+			Title:  title,
+			Public: opts.Public,
+		}}
+		b.rulesets[rs.ID] = rs
+	}
+
+	now := time.Now()
+	number := len(rs.revisions) + 1
+	rs.UpdatedAt = now
+	rs.revisions = append(rs.revisions, memoryRevision{
+		Revision: Revision{
+			Version:   fmt.Sprintf("rev%d", number),
+			Number:    number,
+			UpdatedAt: now,
+		},
+		meta:  metaContent,
+		files: stored,
+	})
+
+	return &PutResult{ID: rs.ID, Changes: changes}, nil
+}
+
+func (b *Memory) Delete(id string) error {
+	if _, exists := b.rulesets[id]; !exists {
+		return fmt.Errorf("rule set not found: %s", id)
+	}
+	delete(b.rulesets, id)
+	return nil
+}
+
+func (b *Memory) History(id string) ([]Revision, error) {
+	rs, exists := b.rulesets[id]
+	if !exists {
+		return nil, fmt.Errorf("rule set not found: %s", id)
+	}
+
+	revisions := make([]Revision, 0, len(rs.revisions))
+	for i := len(rs.revisions) - 1; i >= 0; i-- {
+		revisions = append(revisions, rs.revisions[i].Revision)
+	}
+	return revisions, nil
+}
@@ -0,0 +1,211 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+// BuildRegistry writes every revision of every rule set of src to dir as a static registry
+// that the HTTP backend can read. Revisions are stored under their revision number.
+// dir must be empty or hold a registry built before, and only directories listed in
+// its index are replaced or removed, so unrelated files are never deleted.
+// Rule sets listed in a previous index of dir that no longer exist in src are removed.
+// Rule sets are written to a temporary directory next to dir first, and moved into dir
+// only after all of them were written, so a failed build leaves the registry as it was.
+// progress, if not nil, is called after each rule set is written.
+func BuildRegistry(src Backend, dir string, progress func(entry RegistryEntry)) (*RegistryIndex, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create registry directory: %w", err)
+	}
+	previous, err := readRegistryIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	published := make(map[string]bool)
+	if previous != nil {
+		for _, entry := range previous.Rulesets {
+			published[entry.ID] = true
+		}
+	} else {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read registry directory: %w", err)
+		}
+		if len(entries) > 0 {
+			return nil, fmt.Errorf("%s is not empty and has no %s. Please use an empty directory for a new registry", dir, RegistryIndexFile)
+		}
+	}
+
+	rulesets, err := src.List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stage, err := os.MkdirTemp(filepath.Dir(filepath.Clean(dir)), "."+filepath.Base(filepath.Clean(dir))+"-build-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build directory: %w", err)
+	}
+	defer os.RemoveAll(stage)
+	newDir := filepath.Join(stage, "new")
+	oldDir := filepath.Join(stage, "old")
+
+	index := &RegistryIndex{Format: RegistryFormat, GeneratedAt: time.Now().UTC(), Rulesets: []RegistryEntry{}}
+	for _, rs := range rulesets {
+		if !validRegistryID(rs.ID) {
+			return nil, fmt.Errorf("rule set ID cannot be used as a directory name: %s", rs.ID)
+		}
+		if _, err := os.Lstat(filepath.Join(dir, rs.ID)); err == nil && !published[rs.ID] {
+			return nil, fmt.Errorf("rule set '%s': %s already exists and is not part of the registry", rs.Title, filepath.Join(dir, rs.ID))
+		}
+		entry, err := writeRegistryEntry(src, filepath.Join(newDir, rs.ID), rs)
+		if err != nil {
+			return nil, fmt.Errorf("rule set '%s': %w", rs.Title, err)
+		}
+		if len(entry.Versions) == 0 {
+			// Not managed by rulesctl in any revision
+			continue
+		}
+		index.Rulesets = append(index.Rulesets, *entry)
+		if progress != nil {
+			progress(*entry)
+		}
+	}
+
+	// Every rule set was written; replace the published directories
+	if err := os.MkdirAll(oldDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create build directory: %w", err)
+	}
+	current := make(map[string]bool)
+	for _, entry := range index.Rulesets {
+		current[entry.ID] = true
+		if err := replaceDir(filepath.Join(newDir, entry.ID), filepath.Join(dir, entry.ID), filepath.Join(oldDir, entry.ID)); err != nil {
+			return nil, fmt.Errorf("failed to replace rule set %s: %w", entry.ID, err)
+		}
+	}
+
+	// Remove rule sets that were published before but no longer exist
+	for id := range published {
+		if !current[id] && validRegistryID(id) {
+			if err := os.RemoveAll(filepath.Join(dir, id)); err != nil {
+				return nil, fmt.Errorf("failed to remove rule set %s: %w", id, err)
+			}
+		}
+	}
+
+	// The index is written last, so it never lists versions that are not written yet
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	tmpIndex := filepath.Join(stage, RegistryIndexFile)
+	if err := os.WriteFile(tmpIndex, append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write registry index: %w", err)
+	}
+	if err := os.Rename(tmpIndex, filepath.Join(dir, RegistryIndexFile)); err != nil {
+		return nil, fmt.Errorf("failed to write registry index: %w", err)
+	}
+	return index, nil
+}
+
+// replaceDir moves src to dst. An existing dst is moved to old first and restored
+// if src cannot be moved into its place.
+func replaceDir(src, dst, old string) error {
+	if err := os.Rename(dst, old); err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		old = ""
+	}
+	if err := os.Rename(src, dst); err != nil {
+		if old != "" {
+			os.Rename(old, dst)
+		}
+		return err
+	}
+	return nil
+}
+
+// writeRegistryEntry writes all revisions of a rule set to <rulesetDir>/<number>/.
+func writeRegistryEntry(src Backend, rulesetDir string, rs Ruleset) (*RegistryEntry, error) {
+	history, err := src.History(rs.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &RegistryEntry{ID: rs.ID, Title: rs.Title, Public: rs.Public, UpdatedAt: rs.UpdatedAt, Versions: []RegistryVersion{}}
+	for _, rev := range history {
+		snap, err := src.Get(rs.ID, rev.Version)
+		if err != nil {
+			if errors.Is(err, ErrNoMetadata) {
+				continue
+			}
+			return nil, err
+		}
+
+		version := strconv.Itoa(rev.Number)
+		versionDir := filepath.Join(rulesetDir, version)
+		for _, file := range snap.Meta.Files {
+			path := filepath.FromSlash(file.Path)
+			if !filepath.IsLocal(path) {
+				return nil, fmt.Errorf("invalid file path: %s", file.Path)
+			}
+			content, err := snap.Read(file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", file.Path, err)
+			}
+			if err := os.MkdirAll(filepath.Join(versionDir, filepath.Dir(path)), 0755); err != nil {
+				return nil, fmt.Errorf("failed to create directory: %w", err)
+			}
+			if err := os.WriteFile(filepath.Join(versionDir, path), content, 0644); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", file.Path, err)
+			}
+		}
+
+		metaContent, err := snap.Meta.ToJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate metadata JSON: %v", err)
+		}
+		if err := os.MkdirAll(versionDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(versionDir, gist.MetaFileName), metaContent, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write metadata: %w", err)
+		}
+
+		updatedAt := rev.UpdatedAt
+		if updatedAt.IsZero() {
+			updatedAt = snap.UpdatedAt
+		}
+		entry.Versions = append(entry.Versions, RegistryVersion{Version: version, Number: rev.Number, UpdatedAt: updatedAt})
+	}
+	return entry, nil
+}
+
+// readRegistryIndex reads the index of an existing registry in dir, or returns nil if there is none.
+func readRegistryIndex(dir string) (*RegistryIndex, error) {
+	data, err := os.ReadFile(filepath.Join(dir, RegistryIndexFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read registry index: %w", err)
+	}
+	var index RegistryIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("%s exists but is not a registry index: %w", filepath.Join(dir, RegistryIndexFile), err)
+	}
+	return &index, nil
+}
+
+// validRegistryID reports whether id can be used as the directory of a rule set in a registry.
+func validRegistryID(id string) bool {
+	return filepath.IsLocal(id) && !strings.ContainsAny(id, `/\`) && id != RegistryIndexFile
+}
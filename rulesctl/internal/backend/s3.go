@@ -0,0 +1,347 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+	"github.com/choigawoon/rulesctl/internal/s3"
+)
+
+// S3 stores rule sets in a bucket of S3-compatible object storage such as Amazon S3 or MinIO.
+// Access is controlled by bucket policies; the public flag of a rule set is informational only.
+//
+// Layout:
+//
+//	<prefix><id>/ruleset.json          title and visibility
+//	<prefix><id>/.rulesctl.meta.json   metadata, the index entry of the rule set
+//	<prefix><id>/<path>                rule files
+//
+// Revisions are the object versions of the metadata file, so bucket versioning
+// should be enabled. Without it, only the latest revision is available.
+// Files are written before the metadata, so a rule set never refers to files that are not stored yet.
+type S3 struct {
+	client *s3.Client
+	prefix string // Key prefix of all rule sets, empty or ending with "/"
+}
+
+// NewS3 returns a backend storing rule sets in the bucket of client under prefix.
+func NewS3(client *s3.Client, prefix string) *S3 {
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &S3{client: client, prefix: prefix}
+}
+
+func (b *S3) Name() string {
+	return "s3"
+}
+
+func (b *S3) List(since *time.Time) ([]Ruleset, error) {
+	objects, _, err := b.client.ListObjects(b.prefix, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bucket: %w", err)
+	}
+
+	var rulesets []Ruleset
+	for _, object := range objects {
+		// Only <prefix><id>/.rulesctl.meta.json marks a rule set
+		id, found := strings.CutSuffix(strings.TrimPrefix(object.Key, b.prefix), "/"+gist.MetaFileName)
+		if !found || id == "" || strings.Contains(id, "/") {
+			continue
+		}
+		if since != nil && object.LastModified.Before(*since) {
+			continue
+		}
+		rs, err := b.ruleset(id)
+		if err != nil {
+			continue
+		}
+		rs.UpdatedAt = object.LastModified
+		rulesets = append(rulesets, *rs)
+	}
+	return rulesets, nil
+}
+
+func (b *S3) Get(id, version string) (*Snapshot, error) {
+	rs, err := b.ruleset(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files are matched to the metadata version by time, so the version has to be looked up
+	versions, err := b.client.ListObjectVersions(b.key(id, gist.MetaFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("rule set %s: %w", id, ErrNoMetadata)
+	}
+	metaVersion := &versions[0]
+	if version != "" {
+		metaVersion = nil
+		for i := range versions {
+			if versions[i].VersionID == version {
+				metaVersion = &versions[i]
+			}
+		}
+		if metaVersion == nil {
+			return nil, fmt.Errorf("revision not found: %s", version)
+		}
+	}
+
+	data, _, err := b.client.GetObject(b.key(id, gist.MetaFileName), versionParam(metaVersion.VersionID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+	meta, err := gist.ParseMetadataFromGist(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	rs.UpdatedAt = metaVersion.LastModified
+	latest := metaVersion.IsLatest
+	return &Snapshot{
+		Ruleset: *rs,
+		Version: metaVersion.VersionID,
+		Meta:    meta,
+		Read: func(file gist.FileMetadata) ([]byte, error) {
+			if !validKey(file.Path) {
+				return nil, fmt.Errorf("invalid file path in metadata: %s", file.Path)
+			}
+			key := b.key(id, file.Path)
+			if latest {
+				data, _, err := b.client.GetObject(key, "")
+				return data, err
+			}
+			fileVersion, err := b.fileVersion(key, file.MD5, metaVersion.LastModified)
+			if err != nil {
+				return nil, err
+			}
+			data, _, err := b.client.GetObject(key, versionParam(fileVersion))
+			return data, err
+		},
+	}, nil
+}
+
+func (b *S3) Put(title string, meta *gist.Metadata, files map[string][]byte, opts PutOptions) (*PutResult, error) {
+	rulesets, err := b.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	var existing *Ruleset
+	for i := range rulesets {
+		if rulesets[i].Title == title {
+			existing = &rulesets[i]
+		}
+	}
+
+	var id string
+	var previous *gist.Metadata
+	if existing != nil {
+		if !opts.Force {
+			return nil, fmt.Errorf("rule set already exists. Use --force option to force update")
+		}
+		id = existing.ID
+		snap, err := b.Get(id, "")
+		if err != nil {
+			return nil, err
+		}
+		previous = snap.Meta
+	}
+
+	changes := gist.CompareMetadata(previous, meta)
+	if existing != nil && changes.IsEmpty() {
+		return &PutResult{ID: id, Changes: changes, Skipped: true}, nil
+	}
+
+	if existing == nil {
+		id, err = b.create(title, opts.Public)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Upload only new and changed files; unchanged files keep their stored version
+	stored := make(map[string]string)
+	if previous != nil {
+		for _, file := range previous.Files {
+			stored[file.Path] = file.MD5
+		}
+	}
+	for _, file := range meta.Files {
+		content, exists := files[file.Path]
+		if !exists {
+			return nil, fmt.Errorf("missing content for file: %s", file.Path)
+		}
+		if !validKey(file.Path) {
+			return nil, fmt.Errorf("invalid file path: %s", file.Path)
+		}
+		if md5, exists := stored[file.Path]; exists && md5 == file.MD5 {
+			continue
+		}
+		if _, err := b.client.PutObject(b.key(id, file.Path), content); err != nil {
+			return nil, fmt.Errorf("failed to upload file %s: %w", file.Path, err)
+		}
+	}
+
+	metaContent, err := meta.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate metadata JSON: %v", err)
+	}
+	if _, err := b.client.PutObject(b.key(id, gist.MetaFileName), metaContent); err != nil {
+		return nil, fmt.Errorf("failed to upload metadata: %w", err)
+	}
+
+	// Remove files that are no longer part of the rule set; earlier revisions keep their versions
+	current := make(map[string]bool)
+	for _, file := range meta.Files {
+		current[file.Path] = true
+	}
+	for path := range stored {
+		if !current[path] {
+			if err := b.client.DeleteObject(b.key(id, path)); err != nil {
+				return nil, fmt.Errorf("failed to delete file %s: %w", path, err)
+			}
+		}
+	}
+	return &PutResult{ID: id, Changes: changes}, nil
+}
+
+func (b *S3) Delete(id string) error {
+	if _, err := b.ruleset(id); err != nil {
+		return err
+	}
+	objects, _, err := b.client.ListObjects(b.prefix+id+"/", "")
+	if err != nil {
+		return fmt.Errorf("failed to list rule set: %w", err)
+	}
+	// The metadata goes first, so a partially deleted rule set is no longer listed
+	metaKey := b.key(id, gist.MetaFileName)
+	if err := b.client.DeleteObject(metaKey); err != nil {
+		return fmt.Errorf("failed to delete rule set: %w", err)
+	}
+	for _, object := range objects {
+		if object.Key == metaKey {
+			continue
+		}
+		if err := b.client.DeleteObject(object.Key); err != nil {
+			return fmt.Errorf("failed to delete rule set: %w", err)
+		}
+	}
+	return nil
+}
+
+func (b *S3) History(id string) ([]Revision, error) {
+	if _, err := b.ruleset(id); err != nil {
+		return nil, err
+	}
+	versions, err := b.client.ListObjectVersions(b.key(id, gist.MetaFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+
+	revisions := make([]Revision, 0, len(versions))
+	for i, v := range versions {
+		revisions = append(revisions, Revision{
+			Version:   v.VersionID,
+			Number:    len(versions) - i,
+			UpdatedAt: v.LastModified,
+		})
+	}
+	return revisions, nil
+}
+
+// key returns the object key of a file of a rule set.
+func (b *S3) key(id, file string) string {
+	return b.prefix + id + "/" + file
+}
+
+// ruleset reads the rule set description stored under id.
+func (b *S3) ruleset(id string) (*Ruleset, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || !validKey(id) {
+		return nil, fmt.Errorf("invalid rule set ID: %s", id)
+	}
+
+	data, info, err := b.client.GetObject(b.key(id, rulesetFileName), "")
+	if err != nil {
+		if errors.Is(err, s3.ErrNotFound) {
+			return nil, fmt.Errorf("rule set not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to read rule set %s: %w", id, err)
+	}
+
+	var stored rulesetInfo
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse rule set %s: %w", id, err)
+	}
+	return &Ruleset{ID: id, Title: stored.Title, Public: stored.Public, UpdatedAt: info.LastModified}, nil
+}
+
+// create stores the description of a new rule set and returns its ID.
+// The ID is derived from the title and made unique within the prefix.
+func (b *S3) create(title string, public bool) (string, error) {
+	_, prefixes, err := b.client.ListObjects(b.prefix, "/")
+	if err != nil {
+		return "", fmt.Errorf("failed to list bucket: %w", err)
+	}
+	taken := make(map[string]bool)
+	for _, p := range prefixes {
+		taken[strings.TrimSuffix(strings.TrimPrefix(p, b.prefix), "/")] = true
+	}
+
+	data, err := json.MarshalIndent(rulesetInfo{Title: title, Public: public, CreatedAt: time.Now()}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	base := slugify(title)
+	id := base
+	for i := 2; taken[id]; i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+	if _, err := b.client.PutObject(b.key(id, rulesetFileName), append(data, '\n')); err != nil {
+		return "", fmt.Errorf("failed to write rule set file: %w", err)
+	}
+	return id, nil
+}
+
+// fileVersion returns the version of a file that belongs to the metadata version written at metaTime.
+// That is the newest version written no later than the metadata, preferring one whose
+// ETag matches the MD5 recorded in the metadata (ETags of single part uploads are the MD5 of the content).
+func (b *S3) fileVersion(key, md5 string, metaTime time.Time) (string, error) {
+	versions, err := b.client.ListObjectVersions(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to list versions of %s: %w", key, err)
+	}
+
+	var candidate *s3.ObjectVersion
+	for i := range versions {
+		v := &versions[i]
+		if v.LastModified.After(metaTime) {
+			continue
+		}
+		if v.ETag == md5 {
+			return v.VersionID, nil
+		}
+		if candidate == nil {
+			candidate = v
+		}
+	}
+	if candidate == nil {
+		return "", fmt.Errorf("no version of %s found for this revision", key)
+	}
+	return candidate.VersionID, nil
+}
+
+// versionParam returns the version ID to request; buckets without versioning use "null".
+func versionParam(versionID string) string {
+	if versionID == "null" {
+		return ""
+	}
+	return versionID
+}
@@ -0,0 +1,312 @@
+package backend
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/choigawoon/rulesctl/internal/s3"
+)
+
+// fakeS3 serves a single versioned bucket with path-style requests.
+type fakeS3 struct {
+	bucket  string
+	mu      sync.Mutex
+	clock   time.Time
+	nextID  int
+	objects map[string][]*fakeObjectVersion // Newest version last
+}
+
+type fakeObjectVersion struct {
+	id       string
+	data     []byte
+	modified time.Time
+	deleted  bool // Delete marker
+}
+
+func newFakeS3(t *testing.T, bucket string) *httptest.Server {
+	f := &fakeS3{
+		bucket:  bucket,
+		clock:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		objects: make(map[string][]*fakeObjectVersion),
+	}
+	return httptest.NewServer(f)
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test-key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	path, found := strings.CutPrefix(r.URL.Path, "/"+f.bucket)
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`<Error><Code>NoSuchBucket</Code></Error>`))
+		return
+	}
+	key := strings.TrimPrefix(path, "/")
+	query := r.URL.Query()
+
+	if key == "" {
+		if _, versions := query["versions"]; versions {
+			f.listVersions(w, query.Get("prefix"))
+		} else {
+			f.list(w, query.Get("prefix"), query.Get("delimiter"))
+		}
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		v := f.version(key, query.Get("versionId"))
+		if v == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("X-Amz-Version-Id", v.id)
+		w.Header().Set("Last-Modified", v.modified.Format(http.TimeFormat))
+		if r.Method == "GET" {
+			w.Write(v.data)
+		}
+	case "PUT":
+		data, _ := io.ReadAll(r.Body)
+		v := f.add(key, data, false)
+		w.Header().Set("X-Amz-Version-Id", v.id)
+	case "DELETE":
+		f.add(key, nil, true)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) add(key string, data []byte, deleted bool) *fakeObjectVersion {
+	f.nextID++
+	f.clock = f.clock.Add(time.Second)
+	v := &fakeObjectVersion{id: "v" + strconv.Itoa(f.nextID), data: data, modified: f.clock, deleted: deleted}
+	f.objects[key] = append(f.objects[key], v)
+	return v
+}
+
+// version returns a version of key, or the latest version if id is empty.
+func (f *fakeS3) version(key, id string) *fakeObjectVersion {
+	versions := f.objects[key]
+	if id == "" {
+		if len(versions) == 0 || versions[len(versions)-1].deleted {
+			return nil
+		}
+		return versions[len(versions)-1]
+	}
+	for _, v := range versions {
+		if v.id == id && !v.deleted {
+			return v
+		}
+	}
+	return nil
+}
+
+func (f *fakeS3) sortedKeys(prefix string) []string {
+	var keys []string
+	for key := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, prefix, delimiter string) {
+	type content struct {
+		Key          string
+		ETag         string
+		LastModified time.Time
+	}
+	type commonPrefix struct {
+		Prefix string
+	}
+	result := struct {
+		XMLName        xml.Name `xml:"ListBucketResult"`
+		Contents       []content
+		CommonPrefixes []commonPrefix
+		IsTruncated    bool
+	}{}
+
+	seen := make(map[string]bool)
+	for _, key := range f.sortedKeys(prefix) {
+		v := f.version(key, "")
+		if v == nil {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				p := key[:len(prefix)+i+len(delimiter)]
+				if !seen[p] {
+					seen[p] = true
+					result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: p})
+				}
+				continue
+			}
+		}
+		result.Contents = append(result.Contents, content{Key: key, ETag: etag(v.data), LastModified: v.modified})
+	}
+	xml.NewEncoder(w).Encode(result)
+}
+
+func (f *fakeS3) listVersions(w http.ResponseWriter, prefix string) {
+	type version struct {
+		Key          string
+		VersionId    string
+		ETag         string
+		IsLatest     bool
+		LastModified time.Time
+	}
+	result := struct {
+		XMLName  xml.Name  `xml:"ListVersionsResult"`
+		Versions []version `xml:"Version"`
+	}{}
+
+	for _, key := range f.sortedKeys(prefix) {
+		versions := f.objects[key]
+		for i := len(versions) - 1; i >= 0; i-- {
+			v := versions[i]
+			if v.deleted {
+				continue
+			}
+			result.Versions = append(result.Versions, version{
+				Key:          key,
+				VersionId:    v.id,
+				ETag:         etag(v.data),
+				IsLatest:     i == len(versions)-1,
+				LastModified: v.modified,
+			})
+		}
+	}
+	xml.NewEncoder(w).Encode(result)
+}
+
+func etag(data []byte) string {
+	sum := md5.Sum(data)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func TestS3Backend(t *testing.T) {
+	server := newFakeS3(t, "artifacts")
+	defer server.Close()
+	client := s3.NewClient(server.URL, "artifacts", "us-east-1", s3.Credentials{AccessKeyID: "test-key", SecretAccessKey: "secret"})
+	b := NewS3(client, "/rulesctl/")
+
+	meta, files := testRuleset(map[string]string{"python/lint.mdc": "lint", "b.mdc": "bbb"})
+	result, err := b.Put("Python Rules", meta, files, PutOptions{Public: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if result.ID != "python-rules" || len(result.Changes.Added) != 2 {
+		t.Errorf("Put 결과 = %+v; want ID python-rules, 2 added", result)
+	}
+
+	if _, err := b.Put("Python Rules", meta, files, PutOptions{}); err == nil {
+		t.Error("Force 없이 기존 룰셋을 덮어쓰면 에러가 발생해야 합니다")
+	}
+	result, err = b.Put("Python Rules", meta, files, PutOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if !result.Skipped {
+		t.Error("변경 사항이 없으면 업로드를 건너뛰어야 합니다")
+	}
+
+	meta2, files2 := testRuleset(map[string]string{"python/lint.mdc": "lint v2", "c.mdc": "ccc"})
+	result, err = b.Put("Python Rules", meta2, files2, PutOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if len(result.Changes.Added) != 1 || len(result.Changes.Modified) != 1 || len(result.Changes.Removed) != 1 {
+		t.Errorf("변경 사항 = %+v; want 1 added, 1 modified, 1 removed", result.Changes)
+	}
+
+	// The same title under another ID gets a unique ID
+	other, _ := testRuleset(map[string]string{"x.mdc": "x"})
+	result, err = b.Put("python rules!", other, map[string][]byte{"x.mdc": []byte("x")}, PutOptions{})
+	if err != nil {
+		t.Fatalf("Put 실패: %v", err)
+	}
+	if result.ID != "python-rules-2" {
+		t.Errorf("ID = %s; want python-rules-2", result.ID)
+	}
+
+	rulesets, err := b.List(nil)
+	if err != nil {
+		t.Fatalf("List 실패: %v", err)
+	}
+	if len(rulesets) != 2 {
+		t.Fatalf("List = %+v; want 2 rule sets", rulesets)
+	}
+	if rs, err := FindByTitle(b, "Python Rules"); err != nil || rs.ID != "python-rules" || !rs.Public {
+		t.Errorf("FindByTitle = %+v, %v; want public rule set python-rules", rs, err)
+	}
+
+	history, err := b.History("python-rules")
+	if err != nil {
+		t.Fatalf("History 실패: %v", err)
+	}
+	if len(history) != 2 || history[0].Number != 2 || history[1].Number != 1 {
+		t.Fatalf("History = %+v; want 2 revisions, newest first", history)
+	}
+
+	// Every revision reads the file versions it was uploaded with
+	for _, tt := range []struct {
+		version string
+		want    map[string][]byte
+	}{
+		{version: "", want: files2},
+		{version: history[0].Version, want: files2},
+		{version: history[1].Version, want: files},
+	} {
+		snap, err := b.Get("python-rules", tt.version)
+		if err != nil {
+			t.Fatalf("Get(%q) 실패: %v", tt.version, err)
+		}
+		if len(snap.Meta.Files) != len(tt.want) {
+			t.Errorf("Get(%q) 파일 수 = %d; want %d", tt.version, len(snap.Meta.Files), len(tt.want))
+		}
+		for _, file := range snap.Meta.Files {
+			content, err := snap.Read(file)
+			if err != nil {
+				t.Fatalf("Read(%s) 실패: %v", file.Path, err)
+			}
+			if string(content) != string(tt.want[file.Path]) {
+				t.Errorf("Get(%q) %s 내용 = %q; want %q", tt.version, file.Path, content, tt.want[file.Path])
+			}
+		}
+	}
+	if _, err := b.Get("python-rules", "unknown"); err == nil {
+		t.Error("존재하지 않는 리비전에 대해 에러가 발생해야 합니다")
+	}
+
+	if err := b.Delete("python-rules"); err != nil {
+		t.Fatalf("Delete 실패: %v", err)
+	}
+	if _, err := b.Get("python-rules", ""); err == nil {
+		t.Error("삭제된 룰셋에 대해 에러가 발생해야 합니다")
+	}
+	rulesets, err = b.List(nil)
+	if err != nil || len(rulesets) != 1 {
+		t.Errorf("삭제 후 List = %+v, %v; want 1 rule set", rulesets, err)
+	}
+
+	// Objects outside the prefix are not rule sets
+	if rulesets, err := NewS3(client, "other").List(nil); err != nil || len(rulesets) != 0 {
+		t.Errorf("다른 prefix의 List = %+v, %v; want none", rulesets, err)
+	}
+}
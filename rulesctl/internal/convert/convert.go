@@ -0,0 +1,238 @@
+// Package convert renders Cursor rules into the instruction formats of other AI assistants.
+package convert
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/choigawoon/rulesctl/internal/rule"
+)
+
+// GeneratedMarker identifies files written by Convert. Files without it are never overwritten
+// unless forced, and only files with it are removed when they are no longer generated.
+const GeneratedMarker = "Generated by rulesctl convert"
+
+// windsurfLimit is the maximum size of .windsurfrules that Windsurf reads
+const windsurfLimit = 6000
+
+// File is a file rendered for a target, with a path relative to the project root.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Result is the output of a conversion.
+type Result struct {
+	Target   string
+	Files    []File
+	Dirs     []string // Directories owned by the target; stale generated files in them are removed
+	Warnings []string
+}
+
+type target struct {
+	description string
+	render      func(rules []*rule.Rule, result *Result)
+}
+
+var targets = map[string]target{
+	"copilot":  {"GitHub Copilot (.github/copilot-instructions.md, .github/instructions/)", renderCopilot},
+	"claude":   {"Claude (CLAUDE.md)", renderSingleFile("CLAUDE.md")},
+	"agents":   {"AGENTS.md", renderSingleFile("AGENTS.md")},
+	"windsurf": {"Windsurf (.windsurfrules)", renderWindsurf},
+	"cline":    {"Cline (.clinerules/)", renderCline},
+}
+
+// Targets returns the names of the supported targets.
+func Targets() []string {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Describe returns a short description of a target.
+func Describe(name string) string {
+	return targets[name].description
+}
+
+// Convert renders rules for the named target.
+func Convert(name string, rules []*rule.Rule) (*Result, error) {
+	t, exists := targets[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown target: %s (available: %s)", name, strings.Join(Targets(), ", "))
+	}
+	result := &Result{Target: name}
+	t.render(rules, result)
+	return result, nil
+}
+
+// Write writes the files of a result below root. Existing files that were not generated
+// by rulesctl are not overwritten unless force is set. Generated files left over from
+// an earlier conversion in the directories of the target are removed.
+// Returns the paths of the removed files.
+func Write(root string, result *Result, force bool) ([]string, error) {
+	if !force {
+		var conflicts []string
+		for _, file := range result.Files {
+			if content, err := os.ReadFile(filepath.Join(root, file.Path)); err == nil && !isGenerated(content) {
+				conflicts = append(conflicts, file.Path)
+			}
+		}
+		if len(conflicts) > 0 {
+			return nil, fmt.Errorf("not overwriting files that were not generated by rulesctl: %s. Use --force option to overwrite", strings.Join(conflicts, ", "))
+		}
+	}
+
+	for _, file := range result.Files {
+		path := filepath.Join(root, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, file.Content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+	}
+
+	written := make(map[string]bool)
+	for _, file := range result.Files {
+		written[file.Path] = true
+	}
+	var removed []string
+	for _, dir := range result.Dirs {
+		entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			rel := dir + "/" + entry.Name()
+			if entry.IsDir() || written[rel] {
+				continue
+			}
+			path := filepath.Join(root, filepath.FromSlash(rel))
+			if content, err := os.ReadFile(path); err == nil && isGenerated(content) {
+				if err := os.Remove(path); err != nil {
+					return nil, fmt.Errorf("failed to remove %s: %w", rel, err)
+				}
+				removed = append(removed, rel)
+			}
+		}
+	}
+	return removed, nil
+}
+
+func isGenerated(content []byte) bool {
+	return bytes.Contains(content, []byte(GeneratedMarker))
+}
+
+func header(target string) string {
+	return fmt.Sprintf("<!-- %s from .cursor/rules. Edit the .mdc files and run 'rulesctl convert --to %s' instead of editing this file. -->\n", GeneratedMarker, target)
+}
+
+// renderSection renders a rule for a single instruction file. Frontmatter the format
+// cannot express is written as a note above the rule body.
+func renderSection(sb *strings.Builder, r *rule.Rule) {
+	fmt.Fprintf(sb, "\n<!-- rule: %s -->\n", r.Path)
+	switch r.Type() {
+	case rule.TypeAuto:
+		fmt.Fprintf(sb, "> Applies to files matching: %s\n\n", formatGlobs(r.Globs))
+	case rule.TypeAgent:
+		fmt.Fprintf(sb, "> Apply when relevant: %s\n\n", r.Description)
+	case rule.TypeManual:
+		sb.WriteString("> Apply only when explicitly requested.\n\n")
+	}
+	sb.WriteString(strings.TrimSpace(r.Body))
+	sb.WriteString("\n")
+}
+
+func formatGlobs(globs []string) string {
+	quoted := make([]string, len(globs))
+	for i, glob := range globs {
+		quoted[i] = "`" + glob + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func renderSingleFile(path string) func(rules []*rule.Rule, result *Result) {
+	return func(rules []*rule.Rule, result *Result) {
+		var sb strings.Builder
+		sb.WriteString(header(result.Target))
+		for _, r := range rules {
+			renderSection(&sb, r)
+		}
+		result.Files = append(result.Files, File{Path: path, Content: []byte(sb.String())})
+	}
+}
+
+func renderWindsurf(rules []*rule.Rule, result *Result) {
+	renderSingleFile(".windsurfrules")(rules, result)
+	if size := len(result.Files[0].Content); size > windsurfLimit {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(".windsurfrules is %d characters; Windsurf only reads the first %d", size, windsurfLimit))
+	}
+}
+
+// renderCopilot writes rules with globs as path-specific instruction files with applyTo,
+// and every other rule to the repository-wide instructions.
+func renderCopilot(rules []*rule.Rule, result *Result) {
+	const instructionsDir = ".github/instructions"
+	result.Dirs = append(result.Dirs, instructionsDir)
+
+	var sb strings.Builder
+	sb.WriteString(header(result.Target))
+	for _, r := range rules {
+		if r.Type() != rule.TypeAuto {
+			renderSection(&sb, r)
+			continue
+		}
+
+		var file strings.Builder
+		fmt.Fprintf(&file, "---\napplyTo: %q\n---\n", strings.Join(r.Globs, ","))
+		file.WriteString(header(result.Target))
+		file.WriteString("\n")
+		file.WriteString(strings.TrimSpace(r.Body))
+		file.WriteString("\n")
+		result.Files = append(result.Files, File{
+			Path:    instructionsDir + "/" + fileName(r) + ".instructions.md",
+			Content: []byte(file.String()),
+		})
+	}
+	result.Files = append(result.Files, File{Path: ".github/copilot-instructions.md", Content: []byte(sb.String())})
+}
+
+// renderCline writes each rule to its own file in .clinerules, with paths for rules with globs.
+func renderCline(rules []*rule.Rule, result *Result) {
+	const rulesDir = ".clinerules"
+	result.Dirs = append(result.Dirs, rulesDir)
+
+	for _, r := range rules {
+		var file strings.Builder
+		if r.Type() == rule.TypeAuto {
+			file.WriteString("---\npaths:\n")
+			for _, glob := range r.Globs {
+				fmt.Fprintf(&file, "  - %q\n", glob)
+			}
+			file.WriteString("---\n")
+		}
+		file.WriteString(header(result.Target))
+		switch r.Type() {
+		case rule.TypeAgent:
+			fmt.Fprintf(&file, "\n> Apply when relevant: %s\n", r.Description)
+		case rule.TypeManual:
+			file.WriteString("\n> Apply only when explicitly requested.\n")
+		}
+		file.WriteString("\n")
+		file.WriteString(strings.TrimSpace(r.Body))
+		file.WriteString("\n")
+		result.Files = append(result.Files, File{Path: rulesDir + "/" + fileName(r) + ".md", Content: []byte(file.String())})
+	}
+}
+
+// fileName flattens the rule path into a file name, e.g. "python/lint.mdc" to "python-lint".
+func fileName(r *rule.Rule) string {
+	return strings.ReplaceAll(r.Name(), "/", "-")
+}
@@ -0,0 +1,107 @@
+package convert
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/choigawoon/rulesctl/internal/rule"
+)
+
+func testRules() []*rule.Rule {
+	return []*rule.Rule{
+		{Path: "base.mdc", AlwaysApply: true, Body: "Always be nice."},
+		{Path: "python/lint.mdc", Globs: []string{"*.py"}, Body: "Use ruff."},
+		{Path: "db.mdc", Description: "Database work", Body: "Use migrations."},
+	}
+}
+
+func TestConvert(t *testing.T) {
+	rules := testRules()
+
+	for _, name := range []string{"claude", "agents", "windsurf"} {
+		result, err := Convert(name, rules)
+		if err != nil {
+			t.Fatalf("Convert(%s) 실패: %v", name, err)
+		}
+		if len(result.Files) != 1 {
+			t.Fatalf("Convert(%s) 파일 수 = %d; want 1", name, len(result.Files))
+		}
+		content := string(result.Files[0].Content)
+		for _, want := range []string{GeneratedMarker, "Always be nice.", "Applies to files matching: `*.py`", "Apply when relevant: Database work"} {
+			if !strings.Contains(content, want) {
+				t.Errorf("Convert(%s) 결과에 %q가 없습니다:\n%s", name, want, content)
+			}
+		}
+	}
+
+	result, err := Convert("copilot", rules)
+	if err != nil {
+		t.Fatalf("Convert(copilot) 실패: %v", err)
+	}
+	files := make(map[string]string)
+	for _, file := range result.Files {
+		files[file.Path] = string(file.Content)
+	}
+	scoped := files[".github/instructions/python-lint.instructions.md"]
+	if !strings.HasPrefix(scoped, "---\napplyTo: \"*.py\"\n---\n") || !strings.Contains(scoped, "Use ruff.") {
+		t.Errorf("경로별 instructions 파일이 올바르지 않습니다:\n%s", scoped)
+	}
+	if main := files[".github/copilot-instructions.md"]; strings.Contains(main, "Use ruff.") || !strings.Contains(main, "Always be nice.") {
+		t.Errorf("copilot-instructions.md가 올바르지 않습니다:\n%s", main)
+	}
+
+	result, err = Convert("cline", rules)
+	if err != nil {
+		t.Fatalf("Convert(cline) 실패: %v", err)
+	}
+	if len(result.Files) != 3 || !strings.HasPrefix(string(result.Files[1].Content), "---\npaths:\n  - \"*.py\"\n---\n") {
+		t.Errorf("cline 결과가 올바르지 않습니다: %+v", result.Files)
+	}
+
+	if _, err := Convert("unknown", rules); err == nil {
+		t.Error("알 수 없는 대상에 대해 에러가 발생해야 합니다")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	root := t.TempDir()
+
+	// Hand-written files are not overwritten
+	if err := os.WriteFile(filepath.Join(root, "CLAUDE.md"), []byte("my notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, _ := Convert("claude", testRules())
+	if _, err := Write(root, result, false); err == nil {
+		t.Error("직접 작성한 파일을 덮어쓰면 에러가 발생해야 합니다")
+	}
+	if _, err := Write(root, result, true); err != nil {
+		t.Fatalf("Write 실패: %v", err)
+	}
+	// Generated files are overwritten
+	if _, err := Write(root, result, false); err != nil {
+		t.Errorf("생성된 파일은 덮어써야 합니다: %v", err)
+	}
+
+	// Generated files of removed rules are removed, hand-written files are kept
+	result, _ = Convert("cline", testRules())
+	if _, err := Write(root, result, false); err != nil {
+		t.Fatalf("Write 실패: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".clinerules", "mine.md"), []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, _ = Convert("cline", testRules()[:1])
+	removed, err := Write(root, result, false)
+	if err != nil {
+		t.Fatalf("Write 실패: %v", err)
+	}
+	if !reflect.DeepEqual(removed, []string{".clinerules/db.md", ".clinerules/python-lint.md"}) {
+		t.Errorf("삭제된 파일 = %v", removed)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".clinerules", "mine.md")); err != nil {
+		t.Errorf("직접 작성한 파일이 삭제되었습니다: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package convert
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/choigawoon/rulesctl/internal/rule"
+)
+
+// LegacyFiles are the single-file instruction formats that SplitLegacy can import,
+// relative to the project root, in the order they are looked up.
+var LegacyFiles = []string{
+	".cursorrules",
+	"CLAUDE.md",
+	"AGENTS.md",
+	".github/copilot-instructions.md",
+	"copilot-instructions.md",
+	".windsurfrules",
+}
+
+// SplitLegacy splits a single instruction file into always applied rules, one per section.
+// Sections start at the highest heading level that occurs more than once; a document title
+// above them and any text before the first section become an "overview" rule.
+// name is used for the rule if the file has no sections.
+func SplitLegacy(name string, content []byte) []*rule.Rule {
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	headings := findHeadings(lines)
+
+	level := splitLevel(headings)
+	if level == 0 {
+		body := strings.TrimSpace(strings.Join(lines, "\n"))
+		if body == "" {
+			return nil
+		}
+		return []*rule.Rule{{Path: uniqueName(slug(name), nil) + ".mdc", Description: name, AlwaysApply: true, Body: body + "\n"}}
+	}
+
+	var rules []*rule.Rule
+	used := make(map[string]bool)
+	add := func(title string, sectionLines []string) {
+		body := strings.TrimSpace(strings.Join(sectionLines, "\n"))
+		if body == "" {
+			return
+		}
+		path := uniqueName(slug(title), used) + ".mdc"
+		used[strings.TrimSuffix(path, ".mdc")] = true
+		rules = append(rules, &rule.Rule{Path: path, Description: title, AlwaysApply: true, Body: body + "\n"})
+	}
+
+	// Text before the first section, without a document title that has no content of its own
+	start := len(lines)
+	title := "Overview"
+	for _, h := range headings {
+		if h.level == level {
+			start = h.line
+			break
+		}
+		if h.level < level && title == "Overview" {
+			title = h.text
+		}
+	}
+	preamble := lines[:start]
+	if len(preamble) > 0 && isTitleOnly(preamble, headings) {
+		preamble = nil
+	}
+	add(title, preamble)
+
+	// Headings above the section level after the first section also start a section
+	for i, h := range headings {
+		if h.level > level || h.line < start {
+			continue
+		}
+		end := len(lines)
+		for _, next := range headings[i+1:] {
+			if next.level <= level {
+				end = next.line
+				break
+			}
+		}
+		add(h.text, lines[h.line:end])
+	}
+	return rules
+}
+
+type heading struct {
+	line  int
+	level int
+	text  string
+}
+
+// findHeadings returns the ATX headings ("# Title") outside of fenced code blocks.
+func findHeadings(lines []string) []heading {
+	var headings []heading
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || !strings.HasPrefix(line, "#") {
+			continue
+		}
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if level > 6 || (len(line) > level && line[level] != ' ' && line[level] != '\t') {
+			continue
+		}
+		text := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(line[level:]), "#"))
+		if text == "" {
+			continue
+		}
+		headings = append(headings, heading{line: i, level: level, text: text})
+	}
+	return headings
+}
+
+// splitLevel returns the highest heading level that occurs more than once,
+// or the level of a single heading below a title. Returns 0 if there is nothing to split.
+func splitLevel(headings []heading) int {
+	counts := make(map[int]int)
+	for _, h := range headings {
+		counts[h.level]++
+	}
+	for level := 1; level <= 6; level++ {
+		if counts[level] > 1 {
+			return level
+		}
+	}
+	// A title with a single section
+	for level := 2; level <= 6; level++ {
+		if counts[level] == 1 && counts[level-1] == 1 {
+			return level
+		}
+	}
+	return 0
+}
+
+// isTitleOnly reports whether lines contain nothing but headings and blank lines.
+func isTitleOnly(lines []string, headings []heading) bool {
+	headingLines := make(map[int]bool)
+	for _, h := range headings {
+		headingLines[h.line] = true
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" && !headingLines[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// slug turns a heading into a file name, keeping letters of any script.
+func slug(title string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			sb.WriteRune(r)
+		case r == '-', r == '_', unicode.IsSpace(r), unicode.IsPunct(r), unicode.IsSymbol(r):
+			sb.WriteRune('-')
+		}
+	}
+	s := sb.String()
+	for strings.Contains(s, "--") {
+		s = strings.ReplaceAll(s, "--", "-")
+	}
+	s = strings.Trim(s, "-")
+	if s == "" {
+		return "rules"
+	}
+	return s
+}
+
+func uniqueName(base string, used map[string]bool) string {
+	name := base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	return name
+}
@@ -0,0 +1,65 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/choigawoon/rulesctl/internal/rule"
+)
+
+func TestSplitLegacy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []rule.Rule
+	}{
+		{
+			name:    "no headings",
+			content: "Use tabs.\n",
+			want:    []rule.Rule{{Path: "cursorrules.mdc", Description: ".cursorrules", Body: "Use tabs.\n"}},
+		},
+		{
+			name:    "title without content",
+			content: "# Project\n\n## Style\nUse tabs.\n### Go\ngofmt\n## Tests\nWrite tests.\n",
+			want: []rule.Rule{
+				{Path: "style.mdc", Description: "Style", Body: "## Style\nUse tabs.\n### Go\ngofmt\n"},
+				{Path: "tests.mdc", Description: "Tests", Body: "## Tests\nWrite tests.\n"},
+			},
+		},
+		{
+			name:    "preamble and code fences",
+			content: "# Project\nIntro.\n## Setup\n```sh\n# not a heading\n```\n## Setup\nAgain.\n",
+			want: []rule.Rule{
+				{Path: "project.mdc", Description: "Project", Body: "# Project\nIntro.\n"},
+				{Path: "setup.mdc", Description: "Setup", Body: "## Setup\n```sh\n# not a heading\n```\n"},
+				{Path: "setup-2.mdc", Description: "Setup", Body: "## Setup\nAgain.\n"},
+			},
+		},
+		{
+			name:    "text without title",
+			content: "Be concise.\n# 코드 스타일\nUse tabs.\n# API: v2\nUse REST.\n",
+			want: []rule.Rule{
+				{Path: "overview.mdc", Description: "Overview", Body: "Be concise.\n"},
+				{Path: "코드-스타일.mdc", Description: "코드 스타일", Body: "# 코드 스타일\nUse tabs.\n"},
+				{Path: "api-v2.mdc", Description: "API: v2", Body: "# API: v2\nUse REST.\n"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := SplitLegacy(".cursorrules", []byte(tt.content))
+			got := make([]rule.Rule, len(rules))
+			for i, r := range rules {
+				if !r.AlwaysApply {
+					t.Errorf("%s: alwaysApply = false; want true", r.Path)
+				}
+				got[i] = *r
+				got[i].AlwaysApply = false
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitLegacy = %+v; want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change.
+const DefaultContext = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	a, b int // line index in from / to
+}
+
+// Unified returns a unified diff between from and to.
+// An empty string is returned when both texts are identical.
+func Unified(fromName, toName, from, to string, context int) string {
+	if from == to {
+		return ""
+	}
+
+	a := splitLines(from)
+	b := splitLines(to)
+	ops := lineOps(a, b)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- %s\n", fromName))
+	sb.WriteString(fmt.Sprintf("+++ %s\n", toName))
+
+	for _, h := range hunks(ops, context) {
+		aStart, bStart := ops[h[0]].a, ops[h[0]].b
+		aCount, bCount := 0, 0
+		for _, o := range ops[h[0]:h[1]] {
+			if o.kind != opInsert {
+				aCount++
+			}
+			if o.kind != opDelete {
+				bCount++
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount)))
+		for _, o := range ops[h[0]:h[1]] {
+			switch o.kind {
+			case opEqual:
+				sb.WriteString(" " + a[o.a] + "\n")
+			case opDelete:
+				sb.WriteString("-" + a[o.a] + "\n")
+			case opInsert:
+				sb.WriteString("+" + b[o.b] + "\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// splitLines splits text into lines without their trailing newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.TrimSuffix(s, "\n")
+	return strings.Split(s, "\n")
+}
+
+// lineOps computes an edit script from a to b using the longest common subsequence.
+func lineOps(a, b []string) []op {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{kind: opEqual, a: i, b: j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{kind: opDelete, a: i, b: j})
+			i++
+		default:
+			ops = append(ops, op{kind: opInsert, a: i, b: j})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, op{kind: opDelete, a: i, b: j})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, op{kind: opInsert, a: i, b: j})
+	}
+	return ops
+}
+
+// hunks groups changed operations with their surrounding context.
+// Each hunk is returned as a half-open [start, end) range into ops.
+func hunks(ops []op, context int) [][2]int {
+	var changes []int
+	for i, o := range ops {
+		if o.kind != opEqual {
+			changes = append(changes, i)
+		}
+	}
+
+	var result [][2]int
+	for k := 0; k < len(changes); {
+		first := changes[k]
+		last := first
+		// Merge changes whose context windows overlap
+		for k+1 < len(changes) && changes[k+1]-last <= 2*context+1 {
+			k++
+			last = changes[k]
+		}
+		k++
+
+		start := first - context
+		if start < 0 {
+			start = 0
+		}
+		stop := last + 1 + context
+		if stop > len(ops) {
+			stop = len(ops)
+		}
+		result = append(result, [2]int{start, stop})
+	}
+	return result
+}
+
+// hunkRange formats a "start,count" range for a hunk header.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
@@ -0,0 +1,53 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	t.Run("동일한 내용", func(t *testing.T) {
+		if got := Unified("a", "b", "same\n", "same\n", DefaultContext); got != "" {
+			t.Errorf("동일한 내용에 대해 빈 diff를 기대했지만: %q", got)
+		}
+	})
+
+	t.Run("한 줄 변경", func(t *testing.T) {
+		from := "line1\nline2\nline3\n"
+		to := "line1\nchanged\nline3\n"
+		want := `--- a
++++ b
+@@ -1,3 +1,3 @@
+ line1
+-line2
++changed
+ line3
+`
+		if got := Unified("a", "b", from, to, DefaultContext); got != want {
+			t.Errorf("잘못된 diff:\n%s\nwant:\n%s", got, want)
+		}
+	})
+
+	t.Run("떨어진 변경은 별도 hunk", func(t *testing.T) {
+		var lines []string
+		for i := 0; i < 20; i++ {
+			lines = append(lines, "line")
+		}
+		from := strings.Join(lines, "\n")
+		lines[1] = "first"
+		lines[18] = "second"
+		to := strings.Join(lines, "\n")
+
+		got := Unified("a", "b", from, to, 1)
+		if count := strings.Count(got, "@@ -"); count != 2 {
+			t.Errorf("예상 hunk 수: 2, 실제: %d\n%s", count, got)
+		}
+	})
+
+	t.Run("빈 파일에 추가", func(t *testing.T) {
+		got := Unified("a", "b", "", "new\n", DefaultContext)
+		if !strings.Contains(got, "@@ -0,0 +1 @@") || !strings.Contains(got, "+new") {
+			t.Errorf("잘못된 diff:\n%s", got)
+		}
+	})
+}
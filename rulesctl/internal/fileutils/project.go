@@ -0,0 +1,177 @@
+package fileutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ProjectConfigName is the name of the project configuration file in the project root
+const ProjectConfigName = ".rulesctl.json"
+
+// ProjectConfig is the configuration of a project, read from .rulesctl.json in the project root.
+// Directories are relative to the project root.
+type ProjectConfig struct {
+	RulesDir string            `json:"rules_dir,omitempty"` // Rules directory (default: .cursor/rules)
+	Rulesets map[string]string `json:"rulesets,omitempty"`  // Rules directory of rule sets, keyed by title
+}
+
+// rulesDirOverride is the rules directory given with --dir, relative to the working directory
+var rulesDirOverride string
+
+// SetRulesDir sets the rules directory used instead of the project's.
+// A relative dir is resolved from the working directory; an empty dir restores the default.
+func SetRulesDir(dir string) {
+	rulesDirOverride = dir
+}
+
+// RulesDirOverridden reports whether a rules directory was set with SetRulesDir.
+func RulesDirOverridden() bool {
+	return rulesDirOverride != ""
+}
+
+// workingDir returns the working directory with symlinks resolved.
+func workingDir() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	// Get real path (resolve symlinks)
+	realCwd, err := filepath.EvalSymlinks(cwd)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve symlinks: %w", err)
+	}
+	return realCwd, nil
+}
+
+// ProjectRoot returns the root of the project the working directory belongs to:
+// the nearest directory upwards that contains .rulesctl.json, .cursor/rules or .git.
+// If there is none, the working directory is the project root.
+func ProjectRoot() (string, error) {
+	cwd, err := workingDir()
+	if err != nil {
+		return "", err
+	}
+
+	for dir := cwd; ; dir = filepath.Dir(dir) {
+		for _, marker := range []string{ProjectConfigName, RulesDirName, ".git"} {
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(marker))); err == nil {
+				return dir, nil
+			}
+		}
+		if filepath.Dir(dir) == dir {
+			return cwd, nil
+		}
+	}
+}
+
+// LoadProjectConfig reads .rulesctl.json in root. An empty configuration is returned if it does not exist.
+func LoadProjectConfig(root string) (*ProjectConfig, error) {
+	var cfg ProjectConfig
+	data, err := os.ReadFile(filepath.Join(root, ProjectConfigName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", ProjectConfigName, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ProjectConfigName, err)
+	}
+	return &cfg, nil
+}
+
+// SaveProjectConfig writes .rulesctl.json in root.
+func SaveProjectConfig(root string, cfg *ProjectConfig) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to convert project config to JSON: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ProjectConfigName), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to save %s: %w", ProjectConfigName, err)
+	}
+	return nil
+}
+
+// RulesetDir returns the rules directory configured for a rule set in .rulesctl.json,
+// or "" if the rule set has none.
+func RulesetDir(title string) (string, error) {
+	root, err := ProjectRoot()
+	if err != nil {
+		return "", err
+	}
+	cfg, err := LoadProjectConfig(root)
+	if err != nil {
+		return "", err
+	}
+	if dir := cfg.Rulesets[title]; dir != "" {
+		return filepath.Join(root, filepath.FromSlash(dir)), nil
+	}
+	return "", nil
+}
+
+// ProjectRulesDirs returns the rules directory of the project followed by
+// the other directories configured for rule sets in .rulesctl.json.
+func ProjectRulesDirs() ([]string, error) {
+	if RulesDirOverridden() {
+		dir, err := GetRulesDirPath()
+		if err != nil {
+			return nil, err
+		}
+		return []string{dir}, nil
+	}
+
+	root, err := ProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := LoadProjectConfig(root)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := []string{projectRulesDir(root, cfg)}
+	seen := map[string]bool{dirs[0]: true}
+	for _, dir := range cfg.Rulesets {
+		dir = filepath.Join(root, filepath.FromSlash(dir))
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs[1:])
+	return dirs, nil
+}
+
+func projectRulesDir(root string, cfg *ProjectConfig) string {
+	if cfg.RulesDir != "" {
+		return filepath.Join(root, filepath.FromSlash(cfg.RulesDir))
+	}
+	return filepath.Join(root, filepath.FromSlash(RulesDirName))
+}
+
+// DisplayPath returns path relative to the working directory for messages,
+// or path itself if it cannot be made relative.
+func DisplayPath(path string) string {
+	cwd, err := workingDir()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(cwd, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// DisplayRulesDir returns the rules directory relative to the working directory for messages.
+func DisplayRulesDir() string {
+	dir, err := GetRulesDirPath()
+	if err != nil {
+		return RulesDirName
+	}
+	return DisplayPath(dir)
+}
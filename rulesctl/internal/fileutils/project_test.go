@@ -0,0 +1,87 @@
+package fileutils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProjectConfig(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("심볼릭 링크 해결 실패: %v", err)
+	}
+	sub := filepath.Join(root, "pkg", "sub")
+	for _, dir := range []string{filepath.Join(root, ".git"), sub} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("디렉토리 생성 실패: %v", err)
+		}
+	}
+
+	// 프로젝트 하위 디렉토리에서 실행
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("현재 작업 디렉토리 확인 실패: %v", err)
+	}
+	if err := os.Chdir(sub); err != nil {
+		t.Fatalf("작업 디렉토리 변경 실패: %v", err)
+	}
+	t.Cleanup(func() {
+		SetRulesDir("")
+		os.Chdir(oldDir)
+	})
+
+	if got, err := ProjectRoot(); err != nil || got != root {
+		t.Errorf("ProjectRoot = %s, %v; want %s", got, err, root)
+	}
+	if got, _ := GetRulesDirPath(); got != filepath.Join(root, ".cursor", "rules") {
+		t.Errorf("기본 룰 디렉토리 = %s", got)
+	}
+
+	cfg := &ProjectConfig{
+		RulesDir: ".ai/rules",
+		Rulesets: map[string]string{"api": "services/api/rules"},
+	}
+	if err := SaveProjectConfig(root, cfg); err != nil {
+		t.Fatalf("SaveProjectConfig 실패: %v", err)
+	}
+	loaded, err := LoadProjectConfig(root)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig 실패: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cfg) {
+		t.Errorf("LoadProjectConfig = %+v; want %+v", loaded, cfg)
+	}
+
+	if got, _ := GetRulesDirPath(); got != filepath.Join(root, ".ai", "rules") {
+		t.Errorf("설정된 룰 디렉토리 = %s", got)
+	}
+	if got, _ := RulesetDir("api"); got != filepath.Join(root, "services", "api", "rules") {
+		t.Errorf("RulesetDir(api) = %s", got)
+	}
+	if got, _ := RulesetDir("other"); got != "" {
+		t.Errorf("RulesetDir(other) = %s; want 빈 문자열", got)
+	}
+	dirs, err := ProjectRulesDirs()
+	if err != nil {
+		t.Fatalf("ProjectRulesDirs 실패: %v", err)
+	}
+	want := []string{filepath.Join(root, ".ai", "rules"), filepath.Join(root, "services", "api", "rules")}
+	if !reflect.DeepEqual(dirs, want) {
+		t.Errorf("ProjectRulesDirs = %v; want %v", dirs, want)
+	}
+	if got := DisplayRulesDir(); got != "../../.ai/rules" {
+		t.Errorf("DisplayRulesDir = %s", got)
+	}
+
+	// --dir는 작업 디렉토리 기준이며 프로젝트 설정보다 우선
+	SetRulesDir("local")
+	if got, _ := GetRulesDirPath(); got != filepath.Join(sub, "local") {
+		t.Errorf("--dir 룰 디렉토리 = %s", got)
+	}
+	dirs, _ = ProjectRulesDirs()
+	if !reflect.DeepEqual(dirs, []string{filepath.Join(sub, "local")}) {
+		t.Errorf("--dir ProjectRulesDirs = %v", dirs)
+	}
+}
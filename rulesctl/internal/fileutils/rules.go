@@ -16,23 +16,32 @@ const (
 	RulesDirName = ".cursor/rules"
 )
 
-// GetRulesDirPath returns the path of .cursor/rules directory from the current working directory.
+// GetRulesDirPath returns the path of the rules directory: the directory set with SetRulesDir,
+// or rules_dir of .rulesctl.json in the project root, or .cursor/rules in the project root.
 func GetRulesDirPath() (string, error) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return "", fmt.Errorf("failed to get working directory: %w", err)
+	if rulesDirOverride != "" {
+		if filepath.IsAbs(rulesDirOverride) {
+			return filepath.Clean(rulesDirOverride), nil
+		}
+		cwd, err := workingDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(cwd, rulesDirOverride), nil
 	}
 
-	// Get real path (resolve symlinks)
-	realCwd, err := filepath.EvalSymlinks(cwd)
+	root, err := ProjectRoot()
+	if err != nil {
+		return "", err
+	}
+	cfg, err := LoadProjectConfig(root)
 	if err != nil {
-		return "", fmt.Errorf("failed to resolve symlinks: %w", err)
+		return "", err
 	}
-
-	return filepath.Join(realCwd, RulesDirName), nil
+	return projectRulesDir(root, cfg), nil
 }
 
-// EnsureRulesDir checks if .cursor/rules directory exists and creates it if not.
+// EnsureRulesDir checks if the rules directory exists and creates it if not.
 func EnsureRulesDir() error {
 	dirPath, err := GetRulesDirPath()
 	if err != nil {
@@ -45,19 +54,19 @@ func EnsureRulesDir() error {
 	return nil
 }
 
-// ListLocalRules searches all .mdc files in local .cursor/rules directory and returns their paths and MD5 hashes.
+// ListLocalRules searches all .mdc files in the local rules directory and returns their MD5 hashes
+// keyed by slash separated relative path. A missing or empty directory yields an empty map.
 func ListLocalRules() (map[string]string, error) {
 	rulesDir, err := GetRulesDirPath()
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if .cursor/rules directory exists
+	files := make(map[string]string)
 	if _, err := os.Stat(rulesDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf(".cursor/rules directory does not exist. Initialize with 'rulesctl init' command or create it manually")
+		return files, nil
 	}
 
-	files := make(map[string]string)
 	err = filepath.Walk(rulesDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -75,7 +84,7 @@ func ListLocalRules() (map[string]string, error) {
 				return fmt.Errorf("failed to convert to relative path %s: %w", path, err)
 			}
 
-			files[relPath] = hash
+			files[filepath.ToSlash(relPath)] = hash
 		}
 		return nil
 	})
@@ -84,9 +93,45 @@ func ListLocalRules() (map[string]string, error) {
 		return nil, fmt.Errorf("failed to scan rule files: %w", err)
 	}
 
-	// No files found
-	if len(files) == 0 {
-		return nil, fmt.Errorf("no .mdc files found in .cursor/rules directory. Please add rule files")
+	return files, nil
+}
+
+// HashRulesDir returns the MD5 hashes of all files in the rules directory keyed by relative path.
+// Files managed by rulesctl itself (.rulesctl.*) are skipped. A missing directory yields an empty map.
+func HashRulesDir() (map[string]string, error) {
+	rulesDir, err := GetRulesDirPath()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string)
+	if _, err := os.Stat(rulesDir); os.IsNotExist(err) {
+		return files, nil
+	}
+
+	err = filepath.Walk(rulesDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".rulesctl") {
+			return nil
+		}
+
+		hash, err := calculateMD5(path)
+		if err != nil {
+			return fmt.Errorf("failed to calculate file hash %s: %w", path, err)
+		}
+
+		relPath, err := filepath.Rel(rulesDir, path)
+		if err != nil {
+			return fmt.Errorf("failed to convert to relative path %s: %w", path, err)
+		}
+
+		files[filepath.ToSlash(relPath)] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan rules directory: %w", err)
 	}
 
 	return files, nil
@@ -170,4 +215,4 @@ func CalculateMD5FromBytes(data []byte) string {
 	hash := md5.New()
 	hash.Write(data)
 	return hex.EncodeToString(hash.Sum(nil))
-} 
\ No newline at end of file
+}
@@ -77,8 +77,8 @@ func TestFileUtils(t *testing.T) {
 	t.Run("ListLocalRules", func(t *testing.T) {
 		// 여러 테스트 파일 생성
 		files := map[string][]byte{
-			"test1.mdc": []byte("test1"),
-			"test2.mdc": []byte("test2"),
+			"test1.mdc":     []byte("test1"),
+			"test2.mdc":     []byte("test2"),
 			"sub/test3.mdc": []byte("test3"),
 		}
 
@@ -158,6 +158,11 @@ func TestFileUtils(t *testing.T) {
 
 		// 2. 권한 테스트
 		t.Run("파일 권한", func(t *testing.T) {
+			// root 권한에서는 파일 권한이 적용되지 않음
+			if os.Geteuid() == 0 {
+				t.Skip("root 권한으로 실행 중이므로 권한 테스트를 건너뜁니다")
+			}
+
 			// 읽기 전용 파일 생성
 			path := "readonly.mdc"
 			err := SaveRuleFile(path, []byte("readonly content"))
@@ -251,4 +256,26 @@ func TestFileUtils(t *testing.T) {
 			}
 		})
 	})
-} 
\ No newline at end of file
+}
+
+func TestListLocalRulesEmpty(t *testing.T) {
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("현재 작업 디렉토리 확인 실패: %v", err)
+	}
+	defer os.Chdir(oldDir)
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("작업 디렉토리 변경 실패: %v", err)
+	}
+
+	// 디렉토리가 없거나 비어 있으면 빈 목록
+	for _, setup := range []func() error{func() error { return nil }, EnsureRulesDir} {
+		if err := setup(); err != nil {
+			t.Fatalf("준비 실패: %v", err)
+		}
+		rules, err := ListLocalRules()
+		if err != nil || len(rules) != 0 {
+			t.Errorf("ListLocalRules = %v, %v; want an empty map", rules, err)
+		}
+	}
+}
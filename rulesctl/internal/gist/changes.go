@@ -0,0 +1,78 @@
+package gist
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// ChangeSet describes how the rule files of a target Metadata differ from a base Metadata.
+// Files are compared by path and MD5 hash.
+type ChangeSet struct {
+	Added    []FileMetadata // Files only present in the target
+	Modified []FileMetadata // Files present in both with different hashes (target entry)
+	Removed  []FileMetadata // Files only present in the base
+}
+
+// IsEmpty reports whether the change set contains no changes.
+func (c *ChangeSet) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Modified) == 0 && len(c.Removed) == 0
+}
+
+// CompareMetadata compares the files of base and target.
+// A nil Metadata is treated as an empty rule set.
+func CompareMetadata(base, target *Metadata) *ChangeSet {
+	baseFiles := make(map[string]FileMetadata)
+	if base != nil {
+		for _, file := range base.Files {
+			baseFiles[file.Path] = file
+		}
+	}
+
+	changes := &ChangeSet{}
+	targetPaths := make(map[string]bool)
+	if target != nil {
+		for _, file := range target.Files {
+			targetPaths[file.Path] = true
+			old, exists := baseFiles[file.Path]
+			if !exists {
+				changes.Added = append(changes.Added, file)
+			} else if old.MD5 != file.MD5 {
+				changes.Modified = append(changes.Modified, file)
+			}
+		}
+	}
+
+	for path, file := range baseFiles {
+		if !targetPaths[path] {
+			changes.Removed = append(changes.Removed, file)
+		}
+	}
+
+	sortFiles(changes.Added)
+	sortFiles(changes.Modified)
+	sortFiles(changes.Removed)
+	return changes
+}
+
+// MetadataFromHashes builds a Metadata from a map of relative path to MD5 hash,
+// such as the one returned by fileutils.ListLocalRules.
+func MetadataFromHashes(hashes map[string]string) *Metadata {
+	meta := NewMetadata()
+	for path, hash := range hashes {
+		relativePath := filepath.ToSlash(path)
+		meta.Files = append(meta.Files, FileMetadata{
+			Path:     relativePath,
+			GistName: convertToGistName(relativePath),
+			MD5:      hash,
+		})
+		meta.updateStructure(relativePath)
+	}
+	sortFiles(meta.Files)
+	return meta
+}
+
+func sortFiles(files []FileMetadata) {
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+}
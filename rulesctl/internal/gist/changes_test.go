@@ -0,0 +1,54 @@
+package gist
+
+import "testing"
+
+func TestCompareMetadata(t *testing.T) {
+	base := &Metadata{
+		Files: []FileMetadata{
+			{Path: "same.mdc", MD5: "aaa"},
+			{Path: "changed.mdc", MD5: "bbb"},
+			{Path: "removed.mdc", MD5: "ccc"},
+		},
+	}
+	target := &Metadata{
+		Files: []FileMetadata{
+			{Path: "same.mdc", MD5: "aaa"},
+			{Path: "changed.mdc", MD5: "ddd"},
+			{Path: "python/added.mdc", MD5: "eee"},
+		},
+	}
+
+	changes := CompareMetadata(base, target)
+
+	if len(changes.Added) != 1 || changes.Added[0].Path != "python/added.mdc" {
+		t.Errorf("잘못된 추가 파일: %+v", changes.Added)
+	}
+	if len(changes.Modified) != 1 || changes.Modified[0].Path != "changed.mdc" || changes.Modified[0].MD5 != "ddd" {
+		t.Errorf("잘못된 수정 파일: %+v", changes.Modified)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0].Path != "removed.mdc" {
+		t.Errorf("잘못된 삭제 파일: %+v", changes.Removed)
+	}
+
+	if !CompareMetadata(base, base).IsEmpty() {
+		t.Error("동일한 메타데이터 비교 결과가 비어있지 않음")
+	}
+
+	if got := CompareMetadata(nil, target); len(got.Added) != 3 {
+		t.Errorf("nil 기준 비교 시 예상 추가 파일 수: 3, 실제: %d", len(got.Added))
+	}
+}
+
+func TestMetadataFromHashes(t *testing.T) {
+	meta := MetadataFromHashes(map[string]string{
+		"python/linting.mdc": "aaa",
+		"hello.mdc":          "bbb",
+	})
+
+	if len(meta.Files) != 2 {
+		t.Fatalf("예상 파일 수: 2, 실제: %d", len(meta.Files))
+	}
+	if meta.Files[0].Path != "hello.mdc" || meta.Files[1].GistName != "python_linting_mdc" {
+		t.Errorf("잘못된 파일 메타데이터: %+v", meta.Files)
+	}
+}
@@ -3,8 +3,10 @@ package gist
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/choigawoon/rulesctl/pkg/config"
 	"github.com/google/go-github/v58/github"
 )
 
@@ -23,8 +25,19 @@ func NewClient() (*Client, error) {
 		return nil, err
 	}
 
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
 	ctx := context.Background()
 	client := github.NewTokenClient(ctx, token)
+	if cfg.IsEnterprise() {
+		client, err = client.WithEnterpriseURLs(cfg.APIBaseURL(), cfg.UploadBaseURL())
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub Enterprise URL: %w", err)
+		}
+	}
 
 	return &Client{
 		client: client,
@@ -32,11 +45,22 @@ func NewClient() (*Client, error) {
 	}, nil
 }
 
-func (c *Client) CreateOrUpdateGist(name string, files map[string]File, force bool, public bool) (string, error) {
+// UploadResult describes the Gist files changed by CreateOrUpdateGist.
+// The metadata file is not counted.
+type UploadResult struct {
+	GistID  string
+	Added   []string   // Gist file names that did not exist before
+	Updated []string   // Gist file names that were overwritten
+	Removed []string   // Gist file names deleted because they are no longer uploaded
+	Changes *ChangeSet // Rule file changes against the remote metadata (nil if it could not be compared)
+	Skipped bool       // No changes were found, so the Gist was not edited
+}
+
+func (c *Client) CreateOrUpdateGist(name string, files map[string]File, force bool, public bool) (*UploadResult, error) {
 	// Search for existing Gist
 	gists, _, err := c.client.Gists.List(c.ctx, "", &github.GistListOptions{})
 	if err != nil {
-		return "", fmt.Errorf("failed to list gists: %v", err)
+		return nil, fmt.Errorf("failed to list gists: %v", err)
 	}
 
 	var existingGist *github.Gist
@@ -47,27 +71,25 @@ func (c *Client) CreateOrUpdateGist(name string, files map[string]File, force bo
 		}
 	}
 
+	if existingGist != nil {
+		if !force {
+			return nil, fmt.Errorf("Gist already exists. Use --force option to force update")
+		}
+		return c.updateGist(*existingGist.ID, name, files)
+	}
+
 	// Create Gist files
 	gistFiles := make(map[github.GistFilename]github.GistFile)
+	result := &UploadResult{}
 	for path, file := range files {
 		filename := github.GistFilename(path)
 		content := file.Content
 		gistFiles[filename] = github.GistFile{
 			Content: &content,
 		}
-	}
-
-	if existingGist != nil {
-		if !force {
-			return "", fmt.Errorf("Gist already exists. Use --force option to force update")
-		}
-		// Update Gist
-		existingGist.Files = gistFiles
-		updatedGist, _, err := c.client.Gists.Edit(c.ctx, *existingGist.ID, existingGist)
-		if err != nil {
-			return "", fmt.Errorf("failed to update Gist: %v", err)
+		if path != MetaFileName {
+			result.Added = append(result.Added, path)
 		}
-		return *updatedGist.ID, nil
 	}
 
 	// Create new Gist
@@ -80,10 +102,121 @@ func (c *Client) CreateOrUpdateGist(name string, files map[string]File, force bo
 
 	createdGist, _, err := c.client.Gists.Create(c.ctx, newGist)
 	if err != nil {
-		return "", fmt.Errorf("failed to create Gist: %v", err)
+		return nil, fmt.Errorf("failed to create Gist: %v", err)
+	}
+
+	result.GistID = *createdGist.ID
+	result.Changes = compareUpload(nil, files)
+	sort.Strings(result.Added)
+	return result, nil
+}
+
+// updateGist replaces the files of an existing Gist with files.
+// Rule files whose hashes match the remote metadata are not sent, and the Gist is not edited at all
+// when nothing changed, so no empty revisions are created.
+// Files of the existing Gist that are not in files are deleted explicitly,
+// because the Gist edit API keeps files that are not mentioned in the request.
+func (c *Client) updateGist(gistID, name string, files map[string]File) (*UploadResult, error) {
+	existingGist, _, err := c.client.Gists.Get(c.ctx, gistID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Gist: %v", err)
+	}
+	existing := existingGist.Files
+
+	result := &UploadResult{GistID: gistID}
+	result.Changes = compareUpload(existing, files)
+
+	// Rule files that are identical to the remote ones
+	unchanged := make(map[string]bool)
+	if result.Changes != nil {
+		changed := make(map[string]bool)
+		for _, file := range result.Changes.Added {
+			changed[file.GistName] = true
+		}
+		for _, file := range result.Changes.Modified {
+			changed[file.GistName] = true
+		}
+		for path := range files {
+			if _, exists := existing[github.GistFilename(path)]; exists && !changed[path] && path != MetaFileName {
+				unchanged[path] = true
+			}
+		}
+	}
+
+	// go-github cannot express deletion (a null file), so the request body is built by hand
+	payloadFiles := make(map[string]interface{})
+	for path, file := range files {
+		if path == MetaFileName || unchanged[path] {
+			continue
+		}
+		payloadFiles[path] = map[string]string{"content": file.Content}
+		if _, exists := existing[github.GistFilename(path)]; exists {
+			result.Updated = append(result.Updated, path)
+		} else {
+			result.Added = append(result.Added, path)
+		}
+	}
+	for filename := range existing {
+		if _, exists := files[string(filename)]; !exists {
+			payloadFiles[string(filename)] = nil
+			result.Removed = append(result.Removed, string(filename))
+		}
+	}
+
+	if len(payloadFiles) == 0 && result.Changes != nil {
+		result.Skipped = true
+		return result, nil
+	}
+	if meta, exists := files[MetaFileName]; exists {
+		payloadFiles[MetaFileName] = map[string]string{"content": meta.Content}
+	}
+
+	payload := map[string]interface{}{
+		"description": name,
+		"files":       payloadFiles,
+	}
+
+	req, err := c.client.NewRequest("PATCH", "gists/"+gistID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	if _, err := c.client.Do(c.ctx, req, nil); err != nil {
+		return nil, fmt.Errorf("failed to update Gist: %v", err)
+	}
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Updated)
+	sort.Strings(result.Removed)
+	return result, nil
+}
+
+// compareUpload compares the metadata being uploaded with the metadata of the existing Gist.
+// A nil existing map stands for a new Gist, so every file is added.
+// Returns nil if either metadata file is missing or cannot be parsed.
+func compareUpload(existing map[github.GistFilename]github.GistFile, files map[string]File) *ChangeSet {
+	localFile, exists := files[MetaFileName]
+	if !exists {
+		return nil
+	}
+	localMeta, err := ParseMetadataFromGist(localFile.Content)
+	if err != nil {
+		return nil
+	}
+
+	if existing == nil {
+		return CompareMetadata(nil, localMeta)
+	}
+
+	remoteFile, exists := existing[MetaFileName]
+	if !exists || remoteFile.Content == nil {
+		return nil
+	}
+	remoteMeta, err := ParseMetadataFromGist(*remoteFile.Content)
+	if err != nil {
+		return nil
 	}
 
-	return *createdGist.ID, nil
+	return CompareMetadata(remoteMeta, localMeta)
 }
 
 // FetchUserGists fetches all Gists of the user
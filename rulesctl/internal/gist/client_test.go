@@ -0,0 +1,157 @@
+package gist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v58/github"
+)
+
+// newTestClient는 테스트 서버를 사용하는 Client를 생성합니다.
+func newTestClient(t *testing.T, serverURL string) *Client {
+	t.Helper()
+	gh := github.NewClient(nil)
+	u, err := url.Parse(serverURL + "/")
+	if err != nil {
+		t.Fatalf("URL 파싱 실패: %v", err)
+	}
+	gh.BaseURL = u
+	return &Client{client: gh, ctx: context.Background()}
+}
+
+// metaJSON은 주어진 파일들로 메타데이터 JSON을 생성합니다.
+func metaJSON(t *testing.T, files ...FileMetadata) string {
+	t.Helper()
+	meta := NewMetadata()
+	meta.Files = files
+	data, err := meta.ToJSON()
+	if err != nil {
+		t.Fatalf("메타데이터 직렬화 실패: %v", err)
+	}
+	return string(data)
+}
+
+func TestCreateOrUpdateGist(t *testing.T) {
+	remoteMeta := metaJSON(t,
+		FileMetadata{Path: "kept.mdc", GistName: "kept_mdc", MD5: "111"},
+		FileMetadata{Path: "old.mdc", GistName: "old_mdc", MD5: "222"},
+	)
+
+	var patch map[string]interface{}
+	patched := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/gists":
+			w.Write([]byte(`[{"id":"gist-1","description":"my-rules","files":{
+				".rulesctl.meta.json":{"filename":".rulesctl.meta.json"},
+				"kept_mdc":{"filename":"kept_mdc"},
+				"old_mdc":{"filename":"old_mdc"}}}]`))
+		case r.Method == "GET" && r.URL.Path == "/gists/gist-1":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": "gist-1",
+				"files": map[string]interface{}{
+					MetaFileName: map[string]string{"filename": MetaFileName, "content": remoteMeta},
+					"kept_mdc":   map[string]string{"filename": "kept_mdc", "content": "kept"},
+					"old_mdc":    map[string]string{"filename": "old_mdc", "content": "old"},
+				},
+			})
+		case r.Method == "PATCH" && r.URL.Path == "/gists/gist-1":
+			patched = true
+			if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+				t.Errorf("요청 본문 파싱 실패: %v", err)
+			}
+			w.Write([]byte(`{"id":"gist-1"}`))
+		default:
+			t.Errorf("예상치 못한 요청: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	t.Run("force 없이 업데이트", func(t *testing.T) {
+		files := map[string]File{MetaFileName: {Content: remoteMeta}}
+		if _, err := client.CreateOrUpdateGist("my-rules", files, false, false); err == nil {
+			t.Error("에러가 발생해야 하지만 발생하지 않음")
+		}
+	})
+
+	t.Run("변경된 파일만 전송하고 고아 파일 삭제", func(t *testing.T) {
+		patched = false
+		files := map[string]File{
+			MetaFileName: {Content: metaJSON(t,
+				FileMetadata{Path: "kept.mdc", GistName: "kept_mdc", MD5: "111"},
+				FileMetadata{Path: "new.mdc", GistName: "new_mdc", MD5: "333"},
+			)},
+			"kept_mdc": {Content: "kept"},
+			"new_mdc":  {Content: "new"},
+		}
+
+		result, err := client.CreateOrUpdateGist("my-rules", files, true, false)
+		if err != nil {
+			t.Fatalf("CreateOrUpdateGist 실패: %v", err)
+		}
+
+		if !patched || result.Skipped {
+			t.Fatal("Gist가 수정되지 않음")
+		}
+		if len(result.Added) != 1 || len(result.Updated) != 0 || len(result.Removed) != 1 {
+			t.Errorf("잘못된 결과: %+v", result)
+		}
+		if result.Changes == nil || len(result.Changes.Added) != 1 || len(result.Changes.Removed) != 1 {
+			t.Errorf("잘못된 변경 내역: %+v", result.Changes)
+		}
+
+		patchFiles, _ := patch["files"].(map[string]interface{})
+		if value, exists := patchFiles["old_mdc"]; !exists || value != nil {
+			t.Errorf("삭제된 파일이 null로 전송되지 않음: %v", patchFiles)
+		}
+		if _, exists := patchFiles["kept_mdc"]; exists {
+			t.Error("변경되지 않은 파일이 전송됨")
+		}
+		if _, exists := patchFiles[MetaFileName]; !exists {
+			t.Error("메타데이터 파일이 전송되지 않음")
+		}
+	})
+
+	t.Run("변경 없음", func(t *testing.T) {
+		patched = false
+		files := map[string]File{
+			MetaFileName: {Content: metaJSON(t,
+				FileMetadata{Path: "kept.mdc", GistName: "kept_mdc", MD5: "111"},
+				FileMetadata{Path: "old.mdc", GistName: "old_mdc", MD5: "222"},
+			)},
+			"kept_mdc": {Content: "kept"},
+			"old_mdc":  {Content: "old"},
+		}
+
+		result, err := client.CreateOrUpdateGist("my-rules", files, true, false)
+		if err != nil {
+			t.Fatalf("CreateOrUpdateGist 실패: %v", err)
+		}
+		if !result.Skipped || patched {
+			t.Errorf("변경이 없으면 Gist를 수정하지 않아야 함: %+v", result)
+		}
+	})
+}
+
+func TestNewClientEnterprise(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "ghe-token")
+	t.Setenv("GITHUB_API_URL", "https://github.example.com/api/v3")
+
+	client, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient 실패: %v", err)
+	}
+	if got := client.client.BaseURL.String(); got != "https://github.example.com/api/v3/" {
+		t.Errorf("BaseURL = %s; want https://github.example.com/api/v3/", got)
+	}
+	if got := client.client.UploadURL.String(); got != "https://github.example.com/api/uploads/" {
+		t.Errorf("UploadURL = %s; want https://github.example.com/api/uploads/", got)
+	}
+}
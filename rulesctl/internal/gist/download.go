@@ -6,14 +6,28 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/pkg/config"
 )
 
 // FetchGist fetches a Gist with the specified ID.
 func FetchGist(token, gistID string) (*Gist, error) {
+	return FetchGistRevision(token, gistID, "")
+}
+
+// FetchGistRevision fetches a Gist as it was at the given history version (commit SHA).
+// An empty version fetches the latest revision.
+func FetchGistRevision(token, gistID, version string) (*Gist, error) {
 	client := &http.Client{}
-	url := fmt.Sprintf("%s/gists/%s", baseURL, gistID)
+	url := fmt.Sprintf("%s/gists/%s", APIBaseURL(), gistID)
+	if version != "" {
+		url = fmt.Sprintf("%s/%s", url, version)
+	}
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -55,6 +69,24 @@ func FetchGist(token, gistID string) (*Gist, error) {
 	return &gist, nil
 }
 
+// FileContent returns the content of the named file in the Gist.
+// Falls back to the raw URL when the API response does not include the content.
+func (g *Gist) FileContent(name string) (string, error) {
+	file, exists := g.Files[name]
+	if !exists {
+		return "", fmt.Errorf("file not found in Gist: %s", name)
+	}
+	if file.Content != "" || file.Size == 0 {
+		return file.Content, nil
+	}
+
+	data, err := fetchRaw(file.RawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to download file (%s): %w", name, err)
+	}
+	return string(data), nil
+}
+
 // ParseMetadataFromGist는 Gist의 메타데이터 파일 내용을 파싱합니다.
 func ParseMetadataFromGist(content string) (*Metadata, error) {
 	var meta Metadata
@@ -68,15 +100,14 @@ func ParseMetadataFromGist(content string) (*Metadata, error) {
 func CheckConflicts(meta *Metadata) ([]string, error) {
 	var conflicts []string
 
-	// 현재 작업 디렉토리 확인
-	workDir, err := os.Getwd()
+	rulesDir, err := fileutils.GetRulesDirPath()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get working directory: %w", err)
+		return nil, err
 	}
 
 	// 각 파일에 대해 충돌 검사
 	for _, file := range meta.Files {
-		localPath := filepath.Join(workDir, ".cursor", "rules", file.Path)
+		localPath := filepath.Join(rulesDir, file.Path)
 		if _, err := os.Stat(localPath); err == nil {
 			conflicts = append(conflicts, file.Path)
 		}
@@ -85,82 +116,224 @@ func CheckConflicts(meta *Metadata) ([]string, error) {
 	return conflicts, nil
 }
 
-// DownloadFiles downloads files from a Gist to local.
-func DownloadFiles(token, gistID string, meta *Metadata, force bool) error {
-	// Check current working directory
-	workDir, err := os.Getwd()
+// FileReader returns the content of a rule file of a rule set.
+type FileReader func(file FileMetadata) ([]byte, error)
+
+// InstallFiles installs the files of a rule set into the rules directory (see fileutils.GetRulesDirPath).
+// Every file is read into a temporary directory and verified against its MD5 hash
+// before any file is moved to its final location.
+func InstallFiles(meta *Metadata, read FileReader, force bool) error {
+	if err := checkPaths(meta.Files); err != nil {
+		return err
+	}
+
+	rulesDir, err := fileutils.GetRulesDirPath()
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
-	// Set temporary directory path
-	tmpDir := filepath.Join(workDir, ".rulesctl", "tmp", gistID)
-	rulesDir := filepath.Join(workDir, ".cursor", "rules")
+	tmpDir, err := createTmpDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir) // Remove temporary directory after completion
 
-	// Remove existing temporary directory if exists
-	if err := os.RemoveAll(tmpDir); err != nil {
-		return fmt.Errorf("failed to remove existing temporary directory: %w", err)
+	// Download and verify each file
+	if err := fetchVerifiedFiles(read, meta.Files, tmpDir); err != nil {
+		return err
+	}
+
+	// Check for file conflicts
+	if !force {
+		conflicts, err := CheckConflicts(meta)
+		if err != nil {
+			return fmt.Errorf("failed to check conflicts: %w", err)
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("file conflicts detected. Use --force option to overwrite")
+		}
 	}
 
-	// Create temporary directory
-	if err := os.MkdirAll(tmpDir, 0755); err != nil {
-		return fmt.Errorf("failed to create temporary directory: %w", err)
+	// Move verified files to final location
+	return moveVerifiedFiles(tmpDir, rulesDir, meta.Files, force)
+}
+
+// PullResult describes what an incremental pull changed in the rules directory.
+type PullResult struct {
+	Updated   []string // Downloaded because the file was missing or its remote hash changed
+	Unchanged []string // Already identical to the remote file
+	Deleted   []string // Removed because the rule set no longer contains them
+	Skipped   []string // Locally modified files left untouched
+}
+
+// UpdateFiles incrementally updates the rules directory to match meta.
+// installed lists the files of the previously installed version of the rule set (may be nil),
+// and is used to tell locally modified files apart from files that only changed remotely.
+// Only files whose hashes differ are read, and files no longer in the rule set are deleted
+// when unmodified. Locally modified files are skipped unless force is true.
+func UpdateFiles(meta *Metadata, read FileReader, installed []FileMetadata, force bool) (*PullResult, error) {
+	if err := checkPaths(meta.Files); err != nil {
+		return nil, err
+	}
+	if err := checkPaths(installed); err != nil {
+		return nil, err
 	}
-	defer os.RemoveAll(tmpDir) // Remove temporary directory after completion
 
-	// Fetch Gist
-	gist, err := FetchGist(token, gistID)
+	rulesDir, err := fileutils.GetRulesDirPath()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Download and verify each file
+	installedHashes := make(map[string]string)
+	for _, file := range installed {
+		installedHashes[file.Path] = file.MD5
+	}
+
+	// localHash returns the hash of a local file, or "" if it does not exist
+	localHash := func(path string) (string, error) {
+		hash, err := calculateMD5(filepath.Join(rulesDir, path))
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return hash, err
+	}
+
+	result := &PullResult{}
+	var toDownload []FileMetadata
+	remotePaths := make(map[string]bool)
+
 	for _, file := range meta.Files {
-		// Find file in Gist
-		gistFile, exists := gist.Files[file.GistName]
-		if !exists {
-			return fmt.Errorf("file not found in Gist: %s", file.GistName)
+		remotePaths[file.Path] = true
+
+		hash, err := localHash(file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate MD5 hash (%s): %w", file.Path, err)
 		}
 
-		// Download file to temporary directory
-		tmpPath := filepath.Join(tmpDir, file.Path)
-		if err := os.MkdirAll(filepath.Dir(tmpPath), 0755); err != nil {
-			return fmt.Errorf("failed to create temporary directory: %w", err)
+		switch {
+		case hash == file.MD5:
+			result.Unchanged = append(result.Unchanged, file.Path)
+		case hash != "" && hash != installedHashes[file.Path] && !force:
+			// Modified locally (or not installed by this rule set)
+			result.Skipped = append(result.Skipped, file.Path)
+		default:
+			toDownload = append(toDownload, file)
 		}
+	}
 
-		if err := downloadFile(gistFile.RawURL, tmpPath); err != nil {
-			return fmt.Errorf("failed to download file (%s): %w", file.Path, err)
+	var toDelete []string
+	for _, file := range installed {
+		if remotePaths[file.Path] {
+			continue
 		}
 
-		// Verify MD5 hash
-		hash, err := calculateMD5(tmpPath)
+		hash, err := localHash(file.Path)
 		if err != nil {
-			return fmt.Errorf("failed to calculate MD5 hash (%s): %w", file.Path, err)
+			return nil, fmt.Errorf("failed to calculate MD5 hash (%s): %w", file.Path, err)
 		}
 
-		if hash != file.MD5 {
-			return fmt.Errorf("MD5 hash mismatch (%s): expected %s, got %s", file.Path, file.MD5, hash)
+		switch {
+		case hash == "":
+			// Already gone
+		case hash != file.MD5 && !force:
+			result.Skipped = append(result.Skipped, file.Path)
+		default:
+			toDelete = append(toDelete, file.Path)
 		}
 	}
 
-	// Check for file conflicts
-	if !force {
-		conflicts, err := CheckConflicts(meta)
+	if len(toDownload) > 0 {
+		tmpDir, err := createTmpDir()
 		if err != nil {
-			return fmt.Errorf("failed to check conflicts: %w", err)
+			return nil, err
 		}
-		if len(conflicts) > 0 {
-			return fmt.Errorf("file conflicts detected. Use --force option to overwrite")
+		defer os.RemoveAll(tmpDir)
+
+		if err := fetchVerifiedFiles(read, toDownload, tmpDir); err != nil {
+			return nil, err
+		}
+		if err := moveVerifiedFiles(tmpDir, rulesDir, toDownload, true); err != nil {
+			return nil, err
+		}
+		for _, file := range toDownload {
+			result.Updated = append(result.Updated, file.Path)
+		}
+	}
+
+	for _, path := range toDelete {
+		if err := os.Remove(filepath.Join(rulesDir, path)); err != nil {
+			return nil, fmt.Errorf("failed to delete file (%s): %w", path, err)
+		}
+		result.Deleted = append(result.Deleted, path)
+	}
+
+	return result, nil
+}
+
+// checkPaths rejects file paths that would resolve outside the rules directory.
+// Paths come from remote metadata and the lock file, so they are checked before any file is touched.
+func checkPaths(files []FileMetadata) error {
+	for _, file := range files {
+		if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+			return fmt.Errorf("invalid file path in rule set: %s", file.Path)
 		}
 	}
+	return nil
+}
+
+// createTmpDir creates a new temporary directory under .rulesctl/tmp in the project root.
+func createTmpDir() (string, error) {
+	root, err := fileutils.ProjectRoot()
+	if err != nil {
+		return "", err
+	}
+	tmpRoot := filepath.Join(root, ".rulesctl", "tmp")
+	if err := os.MkdirAll(tmpRoot, 0755); err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp(tmpRoot, "install-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	return tmpDir, nil
+}
+
+// fetchVerifiedFiles reads the given files into tmpDir and verifies their MD5 hashes.
+func fetchVerifiedFiles(read FileReader, files []FileMetadata, tmpDir string) error {
+	for _, file := range files {
+		content, err := read(file)
+		if err != nil {
+			return fmt.Errorf("failed to download file (%s): %w", file.Path, err)
+		}
 
-	// Create .cursor/rules directory
+		// Verify MD5 hash
+		hash := fmt.Sprintf("%x", md5.Sum(content))
+		if hash != file.MD5 {
+			return fmt.Errorf("MD5 hash mismatch (%s): expected %s, got %s", file.Path, file.MD5, hash)
+		}
+
+		// Write file to temporary directory
+		tmpPath := filepath.Join(tmpDir, file.Path)
+		if err := os.MkdirAll(filepath.Dir(tmpPath), 0755); err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		if err := os.WriteFile(tmpPath, content, 0644); err != nil {
+			return fmt.Errorf("failed to write file (%s): %w", file.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// moveVerifiedFiles moves verified files from tmpDir into the rules directory.
+func moveVerifiedFiles(tmpDir, rulesDir string, files []FileMetadata, force bool) error {
+	// Create rules directory
 	if err := os.MkdirAll(rulesDir, 0755); err != nil {
-		return fmt.Errorf("failed to create .cursor/rules directory: %w", err)
+		return fmt.Errorf("failed to create rules directory: %w", err)
 	}
 
-	// Move verified files to final location
-	for _, file := range meta.Files {
+	for _, file := range files {
 		tmpPath := filepath.Join(tmpDir, file.Path)
 		finalPath := filepath.Join(rulesDir, file.Path)
 
@@ -176,9 +349,12 @@ func DownloadFiles(token, gistID string, meta *Metadata, force bool) error {
 			}
 		}
 
-		// Move file
+		// Move file; the rules directory may be on another file system than the project
 		if err := os.Rename(tmpPath, finalPath); err != nil {
-			return fmt.Errorf("failed to move file (%s): %w", file.Path, err)
+			content, readErr := os.ReadFile(tmpPath)
+			if readErr != nil || os.WriteFile(finalPath, content, 0644) != nil {
+				return fmt.Errorf("failed to move file (%s): %w", file.Path, err)
+			}
 		}
 	}
 
@@ -201,24 +377,40 @@ func calculateMD5(filepath string) (string, error) {
 	return fmt.Sprintf("%x", hash.Sum(nil)), nil
 }
 
-// downloadFile은 URL에서 파일을 다운로드합니다.
-func downloadFile(url, filepath string) error {
-	resp, err := http.Get(url)
+// fetchRaw는 URL의 내용을 메모리로 읽어옵니다.
+// GitHub Enterprise Server의 raw URL에는 해당 호스트의 토큰을 함께 전송합니다.
+func fetchRaw(rawURL string) ([]byte, error) {
+	req, err := http.NewRequest("GET", rawURL, nil)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if cfg, err := config.LoadConfig(); err == nil && cfg.Token != "" && isEnterpriseURL(cfg, rawURL) {
+		req.Header.Set("Authorization", "token "+cfg.Token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download file: %s", resp.Status)
+		return nil, fmt.Errorf("failed to download file: %s", resp.Status)
 	}
 
-	out, err := os.Create(filepath)
+	return io.ReadAll(resp.Body)
+}
+
+// isEnterpriseURL reports whether rawURL is served by the configured GitHub Enterprise Server,
+// either on its host or on a subdomain such as gist.<host>.
+func isEnterpriseURL(cfg *config.Config, rawURL string) bool {
+	if !cfg.IsEnterprise() {
+		return false
+	}
+	u, err := url.Parse(rawURL)
 	if err != nil {
-		return err
+		return false
 	}
-	defer out.Close()
-
-	_, err = io.Copy(out, resp.Body)
-	return err
-} 
\ No newline at end of file
+	host := strings.ToLower(u.Host)
+	return host == cfg.APIHost() || strings.HasSuffix(host, "."+cfg.APIHost())
+}
@@ -1,7 +1,9 @@
 package gist
 
 import (
+	"crypto/md5"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -29,6 +31,7 @@ func TestFetchGist(t *testing.T) {
 				Language string `json:"language"`
 				RawURL   string `json:"raw_url"`
 				Size     int    `json:"size"`
+				Content  string `json:"content"`
 			}{
 				MetaFileName: {
 					Filename: MetaFileName,
@@ -155,13 +158,7 @@ func TestCheckConflicts(t *testing.T) {
 	}
 }
 
-func TestDownloadFiles(t *testing.T) {
-	// 테스트 서버 설정
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Write([]byte("test content"))
-	}))
-	defer server.Close()
-
+func TestInstallFiles(t *testing.T) {
 	// 임시 디렉토리 생성
 	tmpDir, err := os.MkdirTemp("", "rulesctl-test-*")
 	if err != nil {
@@ -187,43 +184,161 @@ func TestDownloadFiles(t *testing.T) {
 			{
 				Path:     "test/file.mdc",
 				GistName: "test_file_mdc",
+				MD5:      fmt.Sprintf("%x", md5.Sum([]byte("test content"))),
 			},
 		},
 	}
-
-	// 테스트 Gist
-	testGist := &Gist{
-		Files: map[string]struct {
-			Filename string `json:"filename"`
-			Type     string `json:"type"`
-			Language string `json:"language"`
-			RawURL   string `json:"raw_url"`
-			Size     int    `json:"size"`
-		}{
-			"test_file_mdc": {
-				Filename: "test_file_mdc",
-				RawURL:   server.URL,
-			},
-		},
+	read := func(file FileMetadata) ([]byte, error) {
+		if file.GistName != "test_file_mdc" {
+			return nil, fmt.Errorf("file not found: %s", file.GistName)
+		}
+		return []byte("test content"), nil
 	}
 
-	// baseURL 임시 변경
-	originalBaseURL := baseURL
-	baseURL = server.URL
-	defer func() { baseURL = originalBaseURL }()
-
 	// 테스트 실행
-	if err := DownloadFiles("test-token", "test-gist", meta, true); err != nil {
-		t.Errorf("DownloadFiles 실패: %v", err)
+	if err := InstallFiles(meta, read, true); err != nil {
+		t.Errorf("InstallFiles 실패: %v", err)
 	}
 
 	// 파일 확인
 	content, err := os.ReadFile(filepath.Join(".cursor", "rules", "test", "file.mdc"))
 	if err != nil {
-		t.Errorf("다운로드된 파일 읽기 실패: %v", err)
+		t.Errorf("설치된 파일 읽기 실패: %v", err)
 	}
 
 	if string(content) != "test content" {
 		t.Errorf("잘못된 파일 내용: got %s, want test content", string(content))
 	}
-} 
\ No newline at end of file
+
+	// 해시가 맞지 않으면 아무 파일도 설치하지 않아야 합니다
+	meta.Files[0].Path = "test/other.mdc"
+	meta.Files[0].MD5 = "0"
+	if err := InstallFiles(meta, read, true); err == nil {
+		t.Error("MD5 해시 불일치에 대해 에러가 발생해야 합니다")
+	}
+	if _, err := os.Stat(filepath.Join(".cursor", "rules", "test", "other.mdc")); err == nil {
+		t.Error("검증에 실패한 파일이 설치되었습니다")
+	}
+}
+
+func TestUpdateFiles(t *testing.T) {
+	md5Of := func(s string) string { return fmt.Sprintf("%x", md5.Sum([]byte(s))) }
+
+	// 원격 파일 내용
+	remote := map[string]string{
+		"a_mdc": "a v1",
+		"b_mdc": "b v2",
+		"e_mdc": "e v1",
+	}
+
+	var downloaded []string
+	read := func(file FileMetadata) ([]byte, error) {
+		downloaded = append(downloaded, file.GistName)
+		return []byte(remote[file.GistName]), nil
+	}
+
+	// 임시 디렉토리로 이동
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("현재 디렉토리 확인 실패: %v", err)
+	}
+	defer os.Chdir(originalWd)
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("임시 디렉토리로 이동 실패: %v", err)
+	}
+
+	// 로컬 파일 생성
+	local := map[string]string{
+		"a.mdc": "a v1",
+		"b.mdc": "b v1",
+		"c.mdc": "c v1",
+		"d.mdc": "d edited",
+	}
+	rulesDir := filepath.Join(".cursor", "rules")
+	os.MkdirAll(rulesDir, 0755)
+	for path, content := range local {
+		if err := os.WriteFile(filepath.Join(rulesDir, path), []byte(content), 0644); err != nil {
+			t.Fatalf("테스트 파일 생성 실패: %v", err)
+		}
+	}
+
+	installed := []FileMetadata{
+		{Path: "a.mdc", MD5: md5Of("a v1")},
+		{Path: "b.mdc", MD5: md5Of("b v1")},
+		{Path: "c.mdc", MD5: md5Of("c v1")},
+		{Path: "d.mdc", MD5: md5Of("d v1")},
+	}
+	meta := &Metadata{Files: []FileMetadata{
+		{Path: "a.mdc", GistName: "a_mdc", MD5: md5Of("a v1")},
+		{Path: "b.mdc", GistName: "b_mdc", MD5: md5Of("b v2")},
+		{Path: "e.mdc", GistName: "e_mdc", MD5: md5Of("e v1")},
+	}}
+
+	result, err := UpdateFiles(meta, read, installed, false)
+	if err != nil {
+		t.Fatalf("UpdateFiles 실패: %v", err)
+	}
+
+	if len(result.Updated) != 2 || len(result.Unchanged) != 1 {
+		t.Errorf("잘못된 결과: %+v", result)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0] != "c.mdc" {
+		t.Errorf("잘못된 삭제 파일: %v", result.Deleted)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != "d.mdc" {
+		t.Errorf("잘못된 건너뛴 파일: %v", result.Skipped)
+	}
+	if len(downloaded) != 2 {
+		t.Errorf("변경된 파일만 다운로드해야 함: %v", downloaded)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(rulesDir, "b.mdc"))
+	if string(content) != "b v2" {
+		t.Errorf("잘못된 파일 내용: %s", content)
+	}
+	if _, err := os.Stat(filepath.Join(rulesDir, "c.mdc")); !os.IsNotExist(err) {
+		t.Error("삭제되어야 할 파일이 남아있음")
+	}
+	content, _ = os.ReadFile(filepath.Join(rulesDir, "d.mdc"))
+	if string(content) != "d edited" {
+		t.Errorf("로컬 수정 파일이 변경됨: %s", content)
+	}
+}
+
+func TestInstallFilesRejectsPathTraversal(t *testing.T) {
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("현재 디렉토리 확인 실패: %v", err)
+	}
+	defer os.Chdir(originalWd)
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("임시 디렉토리로 이동 실패: %v", err)
+	}
+
+	content := []byte("evil")
+	read := func(file FileMetadata) ([]byte, error) { return content, nil }
+	evil := FileMetadata{Path: "../x.mdc", MD5: fmt.Sprintf("%x", md5.Sum(content))}
+	outside := filepath.Join(".cursor", "x.mdc") // ../x.mdc relative to .cursor/rules
+
+	if err := InstallFiles(&Metadata{Files: []FileMetadata{evil}}, read, true); err == nil {
+		t.Error("InstallFiles가 규칙 디렉토리 밖의 경로를 허용했습니다")
+	}
+	if _, err := UpdateFiles(&Metadata{Files: []FileMetadata{evil}}, read, nil, true); err == nil {
+		t.Error("UpdateFiles가 규칙 디렉토리 밖의 경로를 허용했습니다")
+	}
+	if _, err := os.Stat(outside); err == nil {
+		t.Fatal("규칙 디렉토리 밖에 파일이 기록되었습니다")
+	}
+
+	// lock 파일의 경로도 삭제하기 전에 거부해야 합니다
+	os.MkdirAll(filepath.Join(".cursor", "rules"), 0755)
+	if err := os.WriteFile(outside, content, 0644); err != nil {
+		t.Fatalf("테스트 파일 생성 실패: %v", err)
+	}
+	if _, err := UpdateFiles(&Metadata{}, read, []FileMetadata{evil}, true); err == nil {
+		t.Error("UpdateFiles가 lock 파일의 규칙 디렉토리 밖 경로를 허용했습니다")
+	}
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("규칙 디렉토리 밖의 파일이 삭제되었습니다: %v", err)
+	}
+}
@@ -4,12 +4,31 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 	
 	"github.com/choigawoon/rulesctl/pkg/config"
 )
 
-var baseURL = "https://api.github.com"
+// baseURL overrides the configured GitHub API base URL when set
+var baseURL = ""
+
+// SetAPIBaseURL makes subsequent API calls use apiURL instead of the configured GitHub API base URL.
+func SetAPIBaseURL(apiURL string) {
+	baseURL = strings.TrimSuffix(apiURL, "/")
+}
+
+// APIBaseURL returns the GitHub API base URL, which points to a GitHub Enterprise Server if configured.
+func APIBaseURL() string {
+	if baseURL != "" {
+		return baseURL
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return config.DefaultAPIURL
+	}
+	return cfg.APIBaseURL()
+}
 
 const MetaFileName = ".rulesctl.meta.json"
 
@@ -28,9 +47,14 @@ type Gist struct {
 		Content  string `json:"content"`
 	} `json:"files"`
 	History []struct {
-		Version   string    `json:"version"`
-		CommitID  string    `json:"commit_id"`
-		UpdatedAt time.Time `json:"updated_at"`
+		Version      string    `json:"version"`
+		CommitID     string    `json:"commit_id"`
+		UpdatedAt    time.Time `json:"updated_at"`
+		ChangeStatus struct {
+			Total     int `json:"total"`
+			Additions int `json:"additions"`
+			Deletions int `json:"deletions"`
+		} `json:"change_status"`
 	} `json:"history"`
 	RevisionNumber int // Same as GitHub web UI numbering (latest is 1)
 }
@@ -50,7 +74,7 @@ func FetchUserGists(since *time.Time) ([]Gist, error) {
 	
 	client := &http.Client{}
 	
-	url := fmt.Sprintf("%s/gists", baseURL)
+	url := fmt.Sprintf("%s/gists", APIBaseURL())
 	if since != nil {
 		url = fmt.Sprintf("%s?since=%s", url, since.Format(time.RFC3339))
 	}
@@ -91,7 +115,7 @@ func FetchUserGists(since *time.Time) ([]Gist, error) {
 
 // FetchGistWithHistory fetches detailed information and history of a specific Gist
 func FetchGistWithHistory(token, gistID string) (*Gist, error) {
-	url := fmt.Sprintf("%s/gists/%s", baseURL, gistID)
+	url := fmt.Sprintf("%s/gists/%s", APIBaseURL(), gistID)
 	
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -99,7 +123,10 @@ func FetchGistWithHistory(token, gistID string) (*Gist, error) {
 	}
 	
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
+	// Add authorization header only if token is provided
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
+	}
 	
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -123,6 +150,26 @@ func FetchGistWithHistory(token, gistID string) (*Gist, error) {
 	return &gist, nil
 }
 
+// FetchRevisionMetadata fetches a Gist revision and parses its rulesctl metadata.
+// The returned metadata is nil if the revision has no metadata file.
+func FetchRevisionMetadata(token, gistID, version string) (*Gist, *Metadata, error) {
+	g, err := FetchGistRevision(token, gistID, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metaFile, exists := g.Files[MetaFileName]
+	if !exists {
+		return g, nil, nil
+	}
+
+	meta, err := ParseMetadataFromGist(metaFile.Content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return g, meta, nil
+}
+
 // DeleteGist deletes a Gist with the specified ID
 func DeleteGist(gistID string) error {
 	// Load token from config
@@ -136,7 +183,7 @@ func DeleteGist(gistID string) error {
 	}
 	
 	client := &http.Client{}
-	url := fmt.Sprintf("%s/gists/%s", baseURL, gistID)
+	url := fmt.Sprintf("%s/gists/%s", APIBaseURL(), gistID)
 	
 	req, err := http.NewRequest("DELETE", url, nil)
 	if err != nil {
@@ -54,6 +54,7 @@ func TestFetchUserGists(t *testing.T) {
 					Language string `json:"language"`
 					RawURL   string `json:"raw_url"`
 					Size     int    `json:"size"`
+					Content  string `json:"content"`
 				}{
 					"test1.mdc": {
 						Filename: "test1.mdc",
@@ -78,7 +79,7 @@ func TestFetchUserGists(t *testing.T) {
 	defer func() { baseURL = oldBaseURL }()
 
 	// Gist 목록 가져오기 테스트
-	gists, err := FetchUserGists(token)
+	gists, err := FetchUserGists(nil)
 	if err != nil {
 		t.Errorf("Gist 목록 가져오기 실패: %v", err)
 	}
@@ -91,4 +92,119 @@ func TestFetchUserGists(t *testing.T) {
 	if gists[0].Description != "테스트 Gist 1" {
 		t.Errorf("예상된 설명: '테스트 Gist 1', 실제: '%s'", gists[0].Description)
 	}
-} 
\ No newline at end of file
+}
+
+func TestFetchRevisionMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gists/test-gist/v2":
+			w.Write([]byte(`{"id":"test-gist","files":{".rulesctl.meta.json":{"content":"{\"files\":[{\"path\":\"a.mdc\",\"md5\":\"111\"}]}"}}}`))
+		case "/gists/test-gist/v1":
+			w.Write([]byte(`{"id":"test-gist","files":{"notes.txt":{"content":"hello"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	oldBaseURL := baseURL
+	baseURL = server.URL
+	defer func() { baseURL = oldBaseURL }()
+
+	_, meta, err := FetchRevisionMetadata("", "test-gist", "v2")
+	if err != nil {
+		t.Fatalf("FetchRevisionMetadata 실패: %v", err)
+	}
+	if meta == nil || len(meta.Files) != 1 || meta.Files[0].Path != "a.mdc" {
+		t.Errorf("잘못된 메타데이터: %+v", meta)
+	}
+
+	_, meta, err = FetchRevisionMetadata("", "test-gist", "v1")
+	if err != nil {
+		t.Fatalf("FetchRevisionMetadata 실패: %v", err)
+	}
+	if meta != nil {
+		t.Errorf("메타데이터가 없는 revision은 nil이어야 함: %+v", meta)
+	}
+
+	if _, _, err := FetchRevisionMetadata("", "test-gist", "v3"); err == nil {
+		t.Error("존재하지 않는 revision에서 에러가 발생해야 함")
+	}
+}
+
+func TestFetchGistWithHistoryWithoutToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 토큰 없이 호출하면 Authorization 헤더가 없어야 함
+		if _, ok := r.Header["Authorization"]; ok {
+			t.Errorf("토큰 없이 Authorization 헤더가 전송됨: %q", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id":"test-gist","history":[{"version":"v2"},{"version":"v1"}]}`))
+	}))
+	defer server.Close()
+
+	oldBaseURL := baseURL
+	baseURL = server.URL
+	defer func() { baseURL = oldBaseURL }()
+
+	g, err := FetchGistWithHistory("", "test-gist")
+	if err != nil {
+		t.Fatalf("FetchGistWithHistory 실패: %v", err)
+	}
+	if g.RevisionNumber != 2 {
+		t.Errorf("revision 수 = %d; want 2", g.RevisionNumber)
+	}
+}
+
+func TestEnterpriseAPIURL(t *testing.T) {
+	// raw URL이 다른 호스트에 있으면 토큰을 보내지 않아야 함
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("다른 호스트에 토큰이 전송되었습니다")
+		}
+		w.Write([]byte("other content"))
+	}))
+	defer other.Close()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "token ghe-token" {
+			t.Errorf("%s Authorization = %q; want GHE 토큰", r.URL.Path, got)
+		}
+		switch r.URL.Path {
+		case "/api/v3/gists/test-gist":
+			data := map[string]interface{}{
+				"id": "test-gist",
+				"files": map[string]interface{}{
+					"local.mdc": map[string]interface{}{"raw_url": server.URL + "/gist/raw/local.mdc", "size": 13},
+					"other.mdc": map[string]interface{}{"raw_url": other.URL + "/raw/other.mdc", "size": 13},
+				},
+			}
+			json.NewEncoder(w).Encode(data)
+		case "/gist/raw/local.mdc":
+			w.Write([]byte("local content"))
+		default:
+			t.Errorf("예상하지 못한 요청: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("GITHUB_API_URL", server.URL+"/api/v3")
+	t.Setenv("GITHUB_TOKEN", "ghe-token")
+
+	g, err := FetchGist("ghe-token", "test-gist")
+	if err != nil {
+		t.Fatalf("FetchGist 실패: %v", err)
+	}
+
+	content, err := g.FileContent("local.mdc")
+	if err != nil || content != "local content" {
+		t.Errorf("GHE raw 파일 = %q, %v; want %q", content, err, "local content")
+	}
+	content, err = g.FileContent("other.mdc")
+	if err != nil || content != "other content" {
+		t.Errorf("외부 raw 파일 = %q, %v; want %q", content, err, "other content")
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
 )
 
 type FileMetadata struct {
@@ -69,28 +71,34 @@ func convertToGistName(path string) string {
 	return strings.Join(append(dirParts, name), "_")
 }
 
+// AddFile adds a rule file to the metadata. A relative path is relative to the rules directory.
+// An absolute path must be within the rules directory, or within a .cursor/rules directory.
 func (m *Metadata) AddFile(path string) error {
-	// Check if path is already absolute
-	var fullPath string
-	var relativePath string
-	
-	if filepath.IsAbs(path) {
-		fullPath = path
-		// Extract path after .cursor/rules/
-		if idx := strings.Index(path, ".cursor/rules/"); idx != -1 {
-			relativePath = path[idx+len(".cursor/rules/"):]
-		} else {
-			return fmt.Errorf("path is not within .cursor/rules/ directory: %s", path)
-		}
-	} else {
-		// For relative paths
-		dir, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get working directory: %w", err)
-		}
-		fullPath = filepath.Join(dir, ".cursor/rules", path)
-		relativePath = path
+	rulesDir, err := fileutils.GetRulesDirPath()
+	if err != nil {
+		return err
+	}
+
+	if !filepath.IsAbs(path) {
+		return m.addFile(rulesDir, filepath.Join(rulesDir, path))
+	}
+	if rel, err := filepath.Rel(rulesDir, path); err == nil && filepath.IsLocal(rel) {
+		return m.addFile(rulesDir, path)
+	}
+	// Extract path after .cursor/rules/
+	if idx := strings.Index(path, ".cursor/rules/"); idx != -1 {
+		return m.addFile(path[:idx+len(".cursor/rules")], path)
+	}
+	return fmt.Errorf("path is not within the rules directory %s: %s", rulesDir, path)
+}
+
+// addFile adds the file at fullPath, recorded by its path relative to rulesDir.
+func (m *Metadata) addFile(rulesDir, fullPath string) error {
+	path, err := filepath.Rel(rulesDir, fullPath)
+	if err != nil {
+		return fmt.Errorf("failed to convert to relative path %s: %w", fullPath, err)
 	}
+	relativePath := filepath.ToSlash(path)
 
 	// Open file
 	file, err := os.Open(fullPath)
@@ -210,24 +218,26 @@ func PreviewMetadataFromDir(dir string) (*Metadata, error) {
 		return nil, fmt.Errorf("failed to scan directory: %w", err)
 	}
 
-	return PreviewMetadata(paths)
+	meta := NewMetadata()
+	for _, path := range paths {
+		if err := meta.addFile(dir, path); err != nil {
+			return nil, fmt.Errorf("failed to generate metadata (%s): %w", path, err)
+		}
+	}
+	return meta, nil
 }
 
-// PreviewMetadataFromWorkingDir generates metadata from the current working directory's .cursor/rules/ path
-// and returns it in meta.json format.
+// PreviewMetadataFromWorkingDir generates metadata from the rules directory of the current project
+// (.cursor/rules unless configured otherwise) and returns it in meta.json format.
 func PreviewMetadataFromWorkingDir() (*Metadata, error) {
-	// Check current working directory
-	workDir, err := os.Getwd()
+	rulesDir, err := fileutils.GetRulesDirPath()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get working directory: %w", err)
+		return nil, err
 	}
 
-	// Create .cursor/rules path
-	rulesDir := filepath.Join(workDir, ".cursor", "rules")
-
 	// Check if directory exists
 	if _, err := os.Stat(rulesDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf(".cursor/rules directory not found: %s", rulesDir)
+		return nil, fmt.Errorf("%s directory not found: %s", fileutils.DisplayPath(rulesDir), rulesDir)
 	}
 
 	return PreviewMetadataFromDir(rulesDir)
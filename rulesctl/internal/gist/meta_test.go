@@ -176,7 +176,7 @@ func TestPreviewMetadataFromWorkingDir(t *testing.T) {
 		if err == nil {
 			t.Error("에러가 발생해야 하지만 발생하지 않음")
 		}
-		if !strings.Contains(err.Error(), ".cursor/rules 디렉토리를 찾을 수 없습니다") {
+		if !strings.Contains(err.Error(), ".cursor/rules directory not found") {
 			t.Errorf("예상치 못한 에러: %v", err)
 		}
 	})
@@ -0,0 +1,114 @@
+// Package glob matches the globs of Cursor rules against project files,
+// and walks a project the way git sees it, skipping files ignored by .gitignore.
+package glob
+
+import (
+	"path"
+	"strings"
+)
+
+// Match reports whether a slash separated path relative to the project root matches
+// a rule glob. A glob without a slash matches the file name at any depth, as "*.py" does in Cursor;
+// otherwise it is matched from the project root. "**" matches any number of directories,
+// and {a,b} matches either alternative.
+func Match(glob, name string) bool {
+	glob = strings.TrimPrefix(glob, "./")
+	if !strings.Contains(strings.TrimSuffix(glob, "/"), "/") {
+		glob = "**/" + glob
+	}
+	for _, pattern := range expandBraces(glob) {
+		if matchSegments(split(pattern), split(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid reports whether a glob can be matched.
+func Valid(glob string) bool {
+	if strings.Count(glob, "{") != strings.Count(glob, "}") {
+		return false
+	}
+	for _, pattern := range expandBraces(glob) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func split(p string) []string {
+	return strings.Split(strings.Trim(p, "/"), "/")
+}
+
+// matchSegments matches path segments against pattern segments, where "**" matches zero or more segments.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], name[0]); !matched {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// expandBraces expands {a,b} alternatives, e.g. "*.{ts,tsx}" to "*.ts" and "*.tsx".
+func expandBraces(pattern string) []string {
+	start := strings.IndexByte(pattern, '{')
+	if start < 0 {
+		return []string{pattern}
+	}
+	depth := 0
+	for end := start; end < len(pattern); end++ {
+		switch pattern[end] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			var patterns []string
+			for _, alt := range splitAlternatives(pattern[start+1 : end]) {
+				patterns = append(patterns, expandBraces(pattern[:start]+alt+pattern[end+1:])...)
+			}
+			return patterns
+		}
+	}
+	return []string{pattern}
+}
+
+// splitAlternatives splits at commas that are not inside nested braces
+func splitAlternatives(s string) []string {
+	var alts []string
+	depth, last := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				alts = append(alts, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(alts, s[last:])
+}
@@ -0,0 +1,84 @@
+package glob
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		glob string
+		name string
+		want bool
+	}{
+		{"*.py", "main.py", true},
+		{"*.py", "pkg/sub/main.py", true},
+		{"*.py", "main.pyc", false},
+		{"src/*.ts", "src/a.ts", true},
+		{"src/*.ts", "src/lib/a.ts", false},
+		{"src/*.ts", "other/src/a.ts", false},
+		{"src/**/*.ts", "src/a.ts", true},
+		{"src/**/*.ts", "src/lib/deep/a.ts", true},
+		{"./src/**", "src/lib/a.go", true},
+		{"**/test_*.py", "tests/unit/test_a.py", true},
+		{"*.{ts,tsx}", "web/App.tsx", true},
+		{"{api,web}/**/*.go", "web/x/main.go", true},
+		{"{api,web}/**/*.go", "cli/main.go", false},
+		{"Dockerfile", "deploy/Dockerfile", true},
+	}
+
+	for _, tt := range tests {
+		if got := Match(tt.glob, tt.name); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v; want %v", tt.glob, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValid(t *testing.T) {
+	for glob, want := range map[string]bool{
+		"src/**/*.ts": true,
+		"*.{ts,tsx}":  true,
+		"*.{ts":       false,
+		"src/[a.go":   false,
+	} {
+		if got := Valid(glob); got != want {
+			t.Errorf("Valid(%q) = %v; want %v", glob, got, want)
+		}
+	}
+}
+
+func TestFiles(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		".gitignore":           "*.log\n/build/\n# comment\nnode_modules\n!keep.log\n",
+		"main.go":              "",
+		"debug.log":            "",
+		"keep.log":             "",
+		"build/out":            "",
+		"web/build/index.js":   "",
+		"web/node_modules/x":   "",
+		"web/.gitignore":       "generated/\n",
+		"web/generated/a.ts":   "",
+		"web/src/generated.ts": "",
+		".git/HEAD":            "",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("디렉토리 생성 실패: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("파일 생성 실패: %v", err)
+		}
+	}
+
+	files, err := Files(root)
+	if err != nil {
+		t.Fatalf("Files 실패: %v", err)
+	}
+	want := []string{".gitignore", "keep.log", "main.go", "web/.gitignore", "web/build/index.js", "web/src/generated.ts"}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("Files = %v; want %v", files, want)
+	}
+}
@@ -0,0 +1,121 @@
+package glob
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ignorePattern is a line of a .gitignore file
+type ignorePattern struct {
+	base     string // Directory of the .gitignore file, relative to the root
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+// Files returns the files below root as slash separated paths relative to root, sorted.
+// Files and directories ignored by a .gitignore file in root or any subdirectory are skipped,
+// and so is the .git directory.
+func Files(root string) ([]string, error) {
+	var files []string
+	if err := walk(root, "", nil, &files); err != nil {
+		return nil, fmt.Errorf("failed to read project files: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func walk(root, dir string, patterns []ignorePattern, files *[]string) error {
+	abs := filepath.Join(root, filepath.FromSlash(dir))
+	entries, err := os.ReadDir(abs)
+	if err != nil {
+		return err
+	}
+
+	if content, err := os.ReadFile(filepath.Join(abs, ".gitignore")); err == nil {
+		// Copy so sibling directories do not share patterns
+		patterns = append(append([]ignorePattern(nil), patterns...), parseIgnore(dir, content)...)
+	}
+
+	for _, entry := range entries {
+		rel := entry.Name()
+		if dir != "" {
+			rel = dir + "/" + rel
+		}
+		isDir := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			// Symlinks are listed, never followed
+			isDir = false
+		}
+		if (isDir && entry.Name() == ".git") || ignored(patterns, rel, isDir) {
+			continue
+		}
+		if isDir {
+			if err := walk(root, rel, patterns, files); err != nil {
+				return err
+			}
+			continue
+		}
+		*files = append(*files, rel)
+	}
+	return nil
+}
+
+// parseIgnore parses a .gitignore file in dir.
+func parseIgnore(dir string, content []byte) []ignorePattern {
+	var patterns []ignorePattern
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if !strings.HasSuffix(line, `\ `) {
+			line = strings.TrimRight(line, " ")
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		p := ignorePattern{base: dir}
+		if rest, negate := strings.CutPrefix(line, "!"); negate {
+			p.negate = true
+			line = rest
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if rest, dirOnly := strings.CutSuffix(line, "/"); dirOnly {
+			p.dirOnly = true
+			line = rest
+		}
+		// A pattern with a slash is relative to the .gitignore; otherwise it matches at any depth
+		if !strings.Contains(line, "/") {
+			line = "**/" + line
+		}
+		p.segments = split(line)
+		patterns = append(patterns, p)
+	}
+	return patterns
+}
+
+// ignored reports whether the last pattern matching rel excludes it.
+func ignored(patterns []ignorePattern, rel string, isDir bool) bool {
+	result := false
+	for _, p := range patterns {
+		name := rel
+		if p.base != "" {
+			var found bool
+			if name, found = strings.CutPrefix(rel, p.base+"/"); !found {
+				continue
+			}
+		}
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if matchSegments(p.segments, split(name)) {
+			result = !p.negate
+		}
+	}
+	return result
+}
@@ -0,0 +1,186 @@
+// Package lint checks Cursor rule files for mistakes that make Cursor ignore or misapply them.
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/choigawoon/rulesctl/internal/glob"
+	"github.com/choigawoon/rulesctl/internal/rule"
+)
+
+// Severities of a diagnostic
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// DefaultMaxLines is the rule length Cursor recommends not to exceed
+const DefaultMaxLines = 500
+
+// knownKeys are the frontmatter keys Cursor reads
+var knownKeys = map[string]bool{"description": true, "globs": true, "alwaysApply": true}
+
+// Diagnostic is a problem found in a rule file.
+type Diagnostic struct {
+	File     string `json:"file"` // Path relative to the rules directory
+	Line     int    `json:"line"`
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s [%s]", d.File, d.Line, d.Severity, d.Message, d.Code)
+}
+
+// Options configures the checks.
+type Options struct {
+	MaxLines int // Maximum number of lines of a rule file; DefaultMaxLines if zero
+}
+
+// Dir checks every .mdc file in dir. Diagnostics are sorted by file and line.
+func Dir(dir string, opts Options) ([]Diagnostic, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".mdc") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules: %w", err)
+	}
+	return Files(files, opts), nil
+}
+
+// Files checks rule files given by path relative to the rules directory.
+func Files(files map[string][]byte, opts Options) []Diagnostic {
+	if opts.MaxLines == 0 {
+		opts.MaxLines = DefaultMaxLines
+	}
+
+	var diags []Diagnostic
+	report := func(file string, line int, severity, code, format string, args ...any) {
+		diags = append(diags, Diagnostic{File: file, Line: line, Severity: severity, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var rules []*rule.Rule
+	for p, content := range files {
+		r, err := rule.Parse(p, content)
+		var parseErr *rule.ParseError
+		if errors.As(err, &parseErr) {
+			report(p, parseErr.Line, SeverityError, "malformed-frontmatter", "%s", parseErr.Msg)
+			continue
+		}
+		rules = append(rules, r)
+
+		if lines := countLines(content); lines > opts.MaxLines {
+			report(p, 1, SeverityWarning, "too-long", "rule has %d lines; keep rules under %d lines", lines, opts.MaxLines)
+		}
+		if !r.HasFrontmatter() {
+			report(p, 1, SeverityError, "missing-frontmatter", "no frontmatter; Cursor only includes this rule when referenced explicitly")
+		}
+
+		for _, f := range r.Fields {
+			switch {
+			case !knownKeys[f.Key]:
+				report(p, f.Line, SeverityWarning, "unknown-key", "unknown frontmatter key %q is ignored by Cursor", f.Key)
+			case r.Field(f.Key).Line != f.Line:
+				report(p, f.Line, SeverityError, "duplicate-key", "%s is set more than once", f.Key)
+			case f.Key == "alwaysApply" && f.Value != "" && !strings.EqualFold(f.Value, "true") && !strings.EqualFold(f.Value, "false"):
+				report(p, f.Line, SeverityError, "invalid-value", "alwaysApply must be true or false, got %q", f.Value)
+			}
+		}
+
+		if f := r.Field("globs"); f != nil {
+			for _, pattern := range r.Globs {
+				if msg := checkGlob(pattern); msg != "" {
+					report(p, f.Line, SeverityError, "invalid-glob", "invalid glob %q: %s", pattern, msg)
+				}
+			}
+		}
+
+		if strings.TrimSpace(r.Body) == "" {
+			report(p, r.BodyLine, SeverityError, "empty-body", "rule has no content")
+		}
+		if r.HasFrontmatter() && r.Type() == rule.TypeManual {
+			report(p, 1, SeverityWarning, "never-applied", "rule has no alwaysApply, globs or description; it is only included when referenced explicitly")
+		}
+	}
+
+	// Rules with the same description cannot be told apart by the agent
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Path < rules[j].Path
+	})
+	first := make(map[string]string)
+	for _, r := range rules {
+		if r.Description == "" {
+			continue
+		}
+		if other, exists := first[r.Description]; exists {
+			report(r.Path, r.Field("description").Line, SeverityWarning, "duplicate-description", "same description as %s", other)
+			continue
+		}
+		first[r.Description] = r.Path
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].File != diags[j].File {
+			return diags[i].File < diags[j].File
+		}
+		return diags[i].Line < diags[j].Line
+	})
+	return diags
+}
+
+// checkGlob returns why a glob is invalid, or "" if it is valid.
+func checkGlob(pattern string) string {
+	if strings.Count(pattern, "{") != strings.Count(pattern, "}") {
+		// Globs are split at commas, so braces with alternatives are cut in half
+		return "unbalanced braces; write alternatives as separate globs, e.g. *.ts,*.tsx"
+	}
+	if !glob.Valid(pattern) {
+		return "unbalanced brackets or trailing escape"
+	}
+	if strings.HasPrefix(pattern, "/") || strings.HasPrefix(pattern, "./") {
+		return "globs are relative to the project root and must not start with / or ./"
+	}
+	return ""
+}
+
+func countLines(content []byte) int {
+	lines := strings.Count(string(content), "\n")
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
+
+// Errors returns the number of diagnostics with error severity.
+func Errors(diags []Diagnostic) int {
+	count := 0
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
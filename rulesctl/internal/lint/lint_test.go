@@ -0,0 +1,92 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string // code:line
+	}{
+		{
+			name:    "valid",
+			content: "---\ndescription: Python lint\nglobs: *.py,tests/**/*.py\nalwaysApply: false\n---\nUse ruff.\n",
+		},
+		{
+			name:    "not closed",
+			content: "---\ndescription: x\nbody\n",
+			want:    []string{"malformed-frontmatter:1"},
+		},
+		{
+			name:    "missing frontmatter",
+			content: "Use ruff.\n",
+			want:    []string{"missing-frontmatter:1"},
+		},
+		{
+			name:    "keys",
+			content: "---\ndescription: x\nauthor: me\nalwaysApply: yes\nalwaysApply: true\n---\nbody\n",
+			want:    []string{"unknown-key:3", "invalid-value:4", "duplicate-key:5"},
+		},
+		{
+			name:    "globs",
+			content: "---\nglobs: *.{ts,tsx}, src/[a.go, /abs/*.go\n---\nbody\n",
+			want:    []string{"invalid-glob:2", "invalid-glob:2", "invalid-glob:2", "invalid-glob:2"},
+		},
+		{
+			name:    "empty body and never applied",
+			content: "---\ndescription:\nglobs:\nalwaysApply: false\n---\n\n",
+			want:    []string{"never-applied:1", "empty-body:6"},
+		},
+		{
+			name:    "too long",
+			content: "---\nalwaysApply: true\n---\n" + strings.Repeat("line\n", 10),
+			want:    []string{"too-long:1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := Files(map[string][]byte{"a.mdc": []byte(tt.content)}, Options{MaxLines: 12})
+			var got []string
+			for _, d := range diags {
+				got = append(got, fmt.Sprintf("%s:%d", d.Code, d.Line))
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("진단 = %v; want %v\n%v", got, tt.want, diags)
+			}
+		})
+	}
+}
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+	rule := "---\ndescription: Database work\n---\nUse migrations.\n"
+	if err := os.MkdirAll(filepath.Join(dir, "db"), 0755); err != nil {
+		t.Fatalf("디렉토리 생성 실패: %v", err)
+	}
+	for _, p := range []string{"a.mdc", "db/b.mdc"} {
+		if err := os.WriteFile(filepath.Join(dir, p), []byte(rule), 0644); err != nil {
+			t.Fatalf("파일 생성 실패: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(""), 0644); err != nil {
+		t.Fatalf("파일 생성 실패: %v", err)
+	}
+
+	diags, err := Dir(dir, Options{})
+	if err != nil {
+		t.Fatalf("Dir 실패: %v", err)
+	}
+	if len(diags) != 1 || diags[0].File != "db/b.mdc" || diags[0].Code != "duplicate-description" || diags[0].Line != 2 {
+		t.Fatalf("진단 = %v; want duplicate-description in db/b.mdc:2", diags)
+	}
+	if Errors(diags) != 0 {
+		t.Errorf("Errors = %d; want 0", Errors(diags))
+	}
+}
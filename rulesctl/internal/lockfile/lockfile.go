@@ -0,0 +1,153 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/choigawoon/rulesctl/internal/fileutils"
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+const (
+	// FileName is the name of the lock file stored in the rules directory
+	FileName = ".rulesctl.lock"
+
+	lockVersion = 1
+)
+
+// Ruleset records a rule set installed into the rules directory.
+type Ruleset struct {
+	Backend  string              `json:"backend"`            // Storage backend the rule set came from
+	Location string              `json:"location,omitempty"` // URL the backend read from (registry URL for http, GitHub API URL for gist)
+	ID       string              `json:"id"`                 // Rule set ID in the backend (Gist ID for gist)
+	Version  string              `json:"version"`            // Installed version (Gist history SHA for gist)
+	Title    string              `json:"title"`
+	Files    []gist.FileMetadata `json:"files"`
+}
+
+// Lock records which rule set each installed rule file came from.
+type Lock struct {
+	LockVersion int       `json:"lock_version"`
+	Rulesets    []Ruleset `json:"rulesets"`
+}
+
+// Path returns the path of the lock file in the rules directory.
+func Path() (string, error) {
+	rulesDir, err := fileutils.GetRulesDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(rulesDir, FileName), nil
+}
+
+// Load reads the lock file. An empty lock is returned if the file does not exist.
+func Load() (*Lock, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Lock{LockVersion: lockVersion}, nil
+		}
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	var lock Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file: %w", err)
+	}
+	return &lock, nil
+}
+
+// Save writes the lock file with rule sets and files in a stable order.
+func (l *Lock) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	l.LockVersion = lockVersion
+	sort.Slice(l.Rulesets, func(i, j int) bool {
+		if l.Rulesets[i].Backend != l.Rulesets[j].Backend {
+			return l.Rulesets[i].Backend < l.Rulesets[j].Backend
+		}
+		return l.Rulesets[i].ID < l.Rulesets[j].ID
+	})
+	for _, rs := range l.Rulesets {
+		sort.Slice(rs.Files, func(i, j int) bool {
+			return rs.Files[i].Path < rs.Files[j].Path
+		})
+	}
+
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to convert lock to JSON: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create rules directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
+
+// Record adds or replaces the entry of an installed rule set.
+// Files claimed by the new entry are removed from other rule sets, since they were overwritten.
+func (l *Lock) Record(entry Ruleset) {
+	claimed := make(map[string]bool)
+	for _, file := range entry.Files {
+		claimed[file.Path] = true
+	}
+
+	rulesets := make([]Ruleset, 0, len(l.Rulesets)+1)
+	for _, rs := range l.Rulesets {
+		if rs.Backend == entry.Backend && rs.ID == entry.ID {
+			continue
+		}
+		files := make([]gist.FileMetadata, 0, len(rs.Files))
+		for _, file := range rs.Files {
+			if !claimed[file.Path] {
+				files = append(files, file)
+			}
+		}
+		if len(files) == 0 {
+			continue
+		}
+		rs.Files = files
+		rulesets = append(rulesets, rs)
+	}
+
+	l.Rulesets = append(rulesets, entry)
+}
+
+// Find returns the entry of the rule set with the given backend and ID, or nil.
+func (l *Lock) Find(backend, id string) *Ruleset {
+	for i := range l.Rulesets {
+		if l.Rulesets[i].Backend == backend && l.Rulesets[i].ID == id {
+			return &l.Rulesets[i]
+		}
+	}
+	return nil
+}
+
+// Owner returns the rule set that installed the file at the given relative path
+// together with the recorded file metadata, or nil if no rule set owns the file.
+func (l *Lock) Owner(path string) (*Ruleset, *gist.FileMetadata) {
+	path = filepath.ToSlash(path)
+	for i := range l.Rulesets {
+		for j := range l.Rulesets[i].Files {
+			if l.Rulesets[i].Files[j].Path == path {
+				return &l.Rulesets[i], &l.Rulesets[i].Files[j]
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,130 @@
+package lockfile
+
+import (
+	"os"
+	"testing"
+
+	"github.com/choigawoon/rulesctl/internal/gist"
+)
+
+func TestLock(t *testing.T) {
+	// 현재 디렉토리 저장 후 임시 디렉토리로 이동
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("현재 디렉토리 확인 실패: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("임시 디렉토리로 이동 실패: %v", err)
+	}
+
+	t.Run("lock 파일 없음", func(t *testing.T) {
+		lock, err := Load()
+		if err != nil {
+			t.Fatalf("Load 실패: %v", err)
+		}
+		if len(lock.Rulesets) != 0 {
+			t.Errorf("빈 lock을 기대했지만 룰셋 수: %d", len(lock.Rulesets))
+		}
+	})
+
+	t.Run("기록 및 저장", func(t *testing.T) {
+		lock, _ := Load()
+		lock.Record(Ruleset{
+			Backend: "gist",
+			ID:      "gist-a",
+			Title:   "A",
+			Files: []gist.FileMetadata{
+				{Path: "shared.mdc", MD5: "111"},
+				{Path: "a.mdc", MD5: "222"},
+			},
+		})
+		lock.Record(Ruleset{
+			Backend: "gist",
+			ID:      "gist-b",
+			Version: "abc",
+			Title:   "B",
+			Files: []gist.FileMetadata{
+				{Path: "shared.mdc", MD5: "333"},
+			},
+		})
+		if err := lock.Save(); err != nil {
+			t.Fatalf("Save 실패: %v", err)
+		}
+
+		loaded, err := Load()
+		if err != nil {
+			t.Fatalf("Load 실패: %v", err)
+		}
+		if len(loaded.Rulesets) != 2 {
+			t.Fatalf("예상 룰셋 수: 2, 실제: %d", len(loaded.Rulesets))
+		}
+
+		// 나중에 설치된 룰셋이 파일을 소유해야 함
+		owner, file := loaded.Owner("shared.mdc")
+		if owner == nil || owner.ID != "gist-b" || file.MD5 != "333" {
+			t.Errorf("잘못된 파일 소유자: %+v", owner)
+		}
+		if a := loaded.Find("gist", "gist-a"); a == nil || len(a.Files) != 1 {
+			t.Errorf("gist-a 룰셋의 파일이 갱신되지 않음: %+v", a)
+		}
+	})
+
+	t.Run("재설치 시 교체", func(t *testing.T) {
+		lock, _ := Load()
+		lock.Record(Ruleset{
+			Backend: "gist",
+			ID:      "gist-b",
+			Version: "def",
+			Files:   []gist.FileMetadata{{Path: "b.mdc", MD5: "444"}},
+		})
+		if got := lock.Find("gist", "gist-b"); got == nil || got.Version != "def" {
+			t.Errorf("룰셋이 교체되지 않음: %+v", got)
+		}
+		if owner, _ := lock.Owner("shared.mdc"); owner != nil {
+			t.Errorf("이전 파일이 남아있음: %+v", owner)
+		}
+	})
+}
+
+func TestStatus(t *testing.T) {
+	lock := &Lock{
+		Rulesets: []Ruleset{
+			{
+				Backend: "gist",
+				ID:      "gist-a",
+				Files: []gist.FileMetadata{
+					{Path: "same.mdc", MD5: "111"},
+					{Path: "edited.mdc", MD5: "222"},
+					{Path: "gone.mdc", MD5: "333"},
+				},
+			},
+		},
+	}
+	local := map[string]string{
+		"same.mdc":   "111",
+		"edited.mdc": "999",
+		"mine.mdc":   "444",
+	}
+
+	expected := map[string]State{
+		"same.mdc":   StateUnchanged,
+		"edited.mdc": StateModified,
+		"gone.mdc":   StateDeleted,
+		"mine.mdc":   StateUntracked,
+	}
+
+	statuses := lock.Status(local)
+	if len(statuses) != len(expected) {
+		t.Fatalf("예상 상태 수: %d, 실제: %d", len(expected), len(statuses))
+	}
+	for _, st := range statuses {
+		if st.State != expected[st.Path] {
+			t.Errorf("%s: 예상 상태 %s, 실제 %s", st.Path, expected[st.Path], st.State)
+		}
+		if (st.State == StateUntracked) != (st.Ruleset == nil) {
+			t.Errorf("%s: 잘못된 룰셋 연결", st.Path)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package lockfile
+
+import "sort"
+
+// State is the state of a file in the rules directory relative to the lock file.
+type State string
+
+const (
+	StateUnchanged State = "unchanged" // Matches the installed rule set
+	StateModified  State = "modified"  // Edited after installation
+	StateDeleted   State = "deleted"   // Installed but missing locally
+	StateUntracked State = "untracked" // Not owned by any installed rule set
+)
+
+// FileStatus is the state of a single file in the rules directory.
+type FileStatus struct {
+	Path    string
+	State   State
+	Ruleset *Ruleset // nil for untracked files
+}
+
+// Status classifies local files (relative path to MD5 hash) against the installed rule sets.
+// Results are sorted by path.
+func (l *Lock) Status(local map[string]string) []FileStatus {
+	var result []FileStatus
+	tracked := make(map[string]bool)
+
+	for i := range l.Rulesets {
+		rs := &l.Rulesets[i]
+		for _, file := range rs.Files {
+			tracked[file.Path] = true
+			hash, exists := local[file.Path]
+			state := StateUnchanged
+			if !exists {
+				state = StateDeleted
+			} else if hash != file.MD5 {
+				state = StateModified
+			}
+			result = append(result, FileStatus{Path: file.Path, State: state, Ruleset: rs})
+		}
+	}
+
+	for path := range local {
+		if !tracked[path] {
+			result = append(result, FileStatus{Path: path, State: StateUntracked})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
@@ -0,0 +1,331 @@
+// Package rule parses Cursor rule files (.mdc) into their frontmatter and body,
+// and writes them back without touching anything that was not changed.
+package rule
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Rule types, derived from the frontmatter of a rule as Cursor does
+const (
+	TypeAlways = "always" // alwaysApply: true
+	TypeAuto   = "auto"   // Attached when a file matching globs is referenced
+	TypeAgent  = "agent"  // Included by the agent based on the description
+	TypeManual = "manual" // Only included when referenced explicitly
+)
+
+// Rule is a Cursor rule (.mdc file) with its frontmatter.
+type Rule struct {
+	Path        string // Path relative to the rules directory, with forward slashes
+	Description string
+	Globs       []string
+	AlwaysApply bool
+	Body        string // Content after the frontmatter, with \n line endings
+
+	// Fields are the frontmatter entries in file order, including keys Cursor does not use
+	Fields []Field
+	// BodyLine is the line number of the first body line
+	BodyLine int
+
+	// State of the parsed file, used to write it back unchanged
+	raw            []byte
+	parsed         *Rule
+	hasFrontmatter bool
+	frontmatter    []string
+	crlf           bool
+}
+
+// Field is a frontmatter entry.
+type Field struct {
+	Key   string
+	Value string // Raw value after the colon; empty for block lists
+	Items []string
+	Line  int // Line number in the file
+
+	start, end int // Range of frontmatter lines of the field
+}
+
+// ParseError is a malformed frontmatter.
+type ParseError struct {
+	Path string
+	Line int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Msg)
+}
+
+// Type returns the rule type (TypeAlways, TypeAuto, TypeAgent or TypeManual).
+func (r *Rule) Type() string {
+	switch {
+	case r.AlwaysApply:
+		return TypeAlways
+	case len(r.Globs) > 0:
+		return TypeAuto
+	case r.Description != "":
+		return TypeAgent
+	default:
+		return TypeManual
+	}
+}
+
+// Name returns the rule path without the .mdc extension, e.g. "python/lint".
+func (r *Rule) Name() string {
+	return strings.TrimSuffix(r.Path, ".mdc")
+}
+
+// HasFrontmatter reports whether the parsed file starts with a frontmatter block.
+func (r *Rule) HasFrontmatter() bool {
+	return r.hasFrontmatter
+}
+
+// Field returns the first frontmatter entry with key, or nil.
+func (r *Rule) Field(key string) *Field {
+	for i := range r.Fields {
+		if r.Fields[i].Key == key {
+			return &r.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Parse parses a .mdc file. Only the keys used by Cursor (description, globs and alwaysApply)
+// are interpreted; other keys are kept as fields and written back as they were.
+func Parse(path string, content []byte) (*Rule, error) {
+	r := &Rule{Path: path, raw: content, BodyLine: 1}
+	text := string(content)
+	r.crlf = strings.Contains(text, "\r\n")
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	if lines[0] != "---" {
+		r.Body = strings.Join(lines, "\n")
+		r.parsed = r.copy()
+		return r, nil
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], " \t") == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil, &ParseError{Path: path, Line: 1, Msg: "frontmatter is not closed with ---"}
+	}
+	r.hasFrontmatter = true
+	r.frontmatter = lines[1:end]
+	r.Body = strings.Join(lines[end+1:], "\n")
+	r.BodyLine = end + 2
+
+	field := -1
+	for i, line := range r.frontmatter {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// Indented lines and list items continue the previous key
+		if field >= 0 && (line[0] == ' ' || line[0] == '\t' || strings.HasPrefix(trimmed, "- ") || trimmed == "-") {
+			f := &r.Fields[field]
+			if item, isItem := strings.CutPrefix(trimmed, "-"); isItem && f.Value == "" {
+				f.Items = append(f.Items, unquote(item))
+			}
+			f.end = i + 1
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(key) == "" || line[0] == ' ' || line[0] == '\t' {
+			return nil, &ParseError{Path: path, Line: i + 2, Msg: fmt.Sprintf("invalid frontmatter line: %q", trimmed)}
+		}
+		r.Fields = append(r.Fields, Field{
+			Key:   strings.TrimSpace(key),
+			Value: strings.TrimSpace(value),
+			Line:  i + 2,
+			start: i,
+			end:   i + 1,
+		})
+		field = len(r.Fields) - 1
+	}
+
+	for _, f := range r.Fields {
+		switch f.Key {
+		case "description":
+			r.Description = unquote(f.Value)
+		case "globs":
+			r.Globs = append(r.Globs, ParseGlobs(f.Value)...)
+			r.Globs = append(r.Globs, f.Items...)
+		case "alwaysApply":
+			r.AlwaysApply = strings.EqualFold(f.Value, "true")
+		}
+	}
+	r.parsed = r.copy()
+	return r, nil
+}
+
+// copy returns the interpreted values of r
+func (r *Rule) copy() *Rule {
+	return &Rule{Description: r.Description, Globs: slices.Clone(r.Globs), AlwaysApply: r.AlwaysApply, Body: r.Body}
+}
+
+// ParseGlobs parses comma separated globs, optionally written as a YAML flow list.
+func ParseGlobs(value string) []string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		value = value[1 : len(value)-1]
+	}
+	var globs []string
+	for _, glob := range strings.Split(value, ",") {
+		if glob = unquote(glob); glob != "" {
+			globs = append(globs, glob)
+		}
+	}
+	return globs
+}
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+		return s[1 : len(s)-1]
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// Bytes renders the rule as a .mdc file. A parsed rule that was not changed is returned
+// byte for byte; otherwise only the lines of changed keys are rewritten, and comments,
+// other keys and line endings are kept. New rules get all three keys Cursor writes.
+func (r *Rule) Bytes() []byte {
+	if r.parsed == nil {
+		var sb strings.Builder
+		sb.WriteString("---\n")
+		fmt.Fprintf(&sb, "description: %s\n", yamlValue(r.Description))
+		fmt.Fprintf(&sb, "globs: %s\n", strings.Join(r.Globs, ","))
+		fmt.Fprintf(&sb, "alwaysApply: %t\n", r.AlwaysApply)
+		sb.WriteString("---\n")
+		sb.WriteString(r.Body)
+		return []byte(sb.String())
+	}
+
+	old := r.parsed
+	metaChanged := r.Description != old.Description || r.AlwaysApply != old.AlwaysApply || !slices.Equal(r.Globs, old.Globs)
+	if !metaChanged && r.Body == old.Body {
+		return r.raw
+	}
+
+	var text string
+	if !r.hasFrontmatter && !metaChanged {
+		text = r.Body
+	} else {
+		changed := make(map[string]string)
+		if r.Description != old.Description {
+			changed["description"] = yamlValue(r.Description)
+		}
+		if !slices.Equal(r.Globs, old.Globs) {
+			changed["globs"] = strings.Join(r.Globs, ",")
+		}
+		if r.AlwaysApply != old.AlwaysApply {
+			changed["alwaysApply"] = strconv.FormatBool(r.AlwaysApply)
+		}
+		lines := r.setFields(changed)
+		text = "---\n" + strings.Join(append(lines, "---"), "\n") + "\n" + r.Body
+	}
+	if r.crlf {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	return []byte(text)
+}
+
+// setFields replaces the lines of every entry of a changed key with a single "key: value" line
+// and appends the keys that are not set, keeping all other frontmatter lines.
+func (r *Rule) setFields(changed map[string]string) []string {
+	line := func(key string) string {
+		return strings.TrimRight(key+": "+changed[key], " ")
+	}
+
+	var lines []string
+	written := make(map[string]bool)
+	prev := 0
+	for _, f := range r.Fields {
+		if _, isChanged := changed[f.Key]; !isChanged {
+			continue
+		}
+		lines = append(lines, r.frontmatter[prev:f.start]...)
+		if !written[f.Key] {
+			lines = append(lines, line(f.Key))
+			written[f.Key] = true
+		}
+		prev = f.end
+	}
+	lines = append(lines, r.frontmatter[prev:]...)
+
+	for _, key := range []string{"description", "globs", "alwaysApply"} {
+		if _, isChanged := changed[key]; isChanged && !written[key] {
+			lines = append(lines, line(key))
+		}
+	}
+	return lines
+}
+
+// yamlValue quotes a value if it would not be read back as a plain string.
+func yamlValue(s string) string {
+	if s == "" {
+		return ""
+	}
+	if strings.ContainsAny(s, ":#\"'[]{},&*!|>%@`") || strings.TrimSpace(s) != s {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+// Load reads and parses every .mdc file in dir, sorted by path.
+// A file that cannot be read or parsed does not stop the others from loading; it is
+// left out and reported in broken (a *ParseError for malformed frontmatter).
+// err is only set if dir itself cannot be read.
+func Load(dir string) (rules []*Rule, broken []error, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".mdc") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			broken = append(broken, err)
+			return nil
+		}
+		r, err := Parse(filepath.ToSlash(rel), content)
+		if err != nil {
+			broken = append(broken, err)
+			return nil
+		}
+		rules = append(rules, r)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read rules: %w", err)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Path < rules[j].Path
+	})
+	return rules, broken, nil
+}
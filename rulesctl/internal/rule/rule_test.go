@@ -0,0 +1,199 @@
+package rule
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Rule
+		typ     string
+	}{
+		{
+			name:    "always",
+			content: "---\ndescription: Base rules\nglobs:\nalwaysApply: true\n---\n# Base\n",
+			want:    Rule{Description: "Base rules", AlwaysApply: true, Body: "# Base\n"},
+			typ:     TypeAlways,
+		},
+		{
+			name:    "comma separated globs",
+			content: "---\ndescription:\nglobs: *.py, tests/**/*.py\nalwaysApply: false\n---\nbody",
+			want:    Rule{Globs: []string{"*.py", "tests/**/*.py"}, Body: "body"},
+			typ:     TypeAuto,
+		},
+		{
+			name:    "YAML lists",
+			content: "---\nglobs:\n  - \"*.ts\"\n  - '*.tsx'\n---\nbody",
+			want:    Rule{Globs: []string{"*.ts", "*.tsx"}, Body: "body"},
+			typ:     TypeAuto,
+		},
+		{
+			name:    "flow list and CRLF",
+			content: "---\r\nglobs: [\"*.go\", \"go.mod\"]\r\n---\r\nbody\r\n",
+			want:    Rule{Globs: []string{"*.go", "go.mod"}, Body: "body\n"},
+			typ:     TypeAuto,
+		},
+		{
+			name:    "agent requested",
+			content: "---\ndescription: \"Use for database migrations\"\n---\nbody",
+			want:    Rule{Description: "Use for database migrations", Body: "body"},
+			typ:     TypeAgent,
+		},
+		{
+			name:    "no frontmatter",
+			content: "# Just text\n",
+			want:    Rule{Body: "# Just text\n"},
+			typ:     TypeManual,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse("a.mdc", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse 실패: %v", err)
+			}
+			got := Rule{Description: r.Description, Globs: r.Globs, AlwaysApply: r.AlwaysApply, Body: r.Body}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %+v; want %+v", got, tt.want)
+			}
+			if r.Type() != tt.typ {
+				t.Errorf("Type = %s; want %s", r.Type(), tt.typ)
+			}
+			if string(r.Bytes()) != tt.content {
+				t.Errorf("Bytes = %q; want the original content", r.Bytes())
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tt := range []struct {
+		content string
+		line    int
+	}{
+		{content: "---\ndescription: x\n", line: 1},
+		{content: "---\ndescription: x\nnot a key\n---\nbody", line: 3},
+	} {
+		_, err := Parse("bad.mdc", []byte(tt.content))
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Line != tt.line {
+			t.Errorf("Parse(%q) 에러 = %v; want ParseError at line %d", tt.content, err, tt.line)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	r, err := Parse("a.mdc", []byte("---\n# comment\ndescription: x\nglobs:\n  - \"*.go\"\nauthor: me\n---\nbody\n"))
+	if err != nil {
+		t.Fatalf("Parse 실패: %v", err)
+	}
+	if len(r.Fields) != 3 || r.Field("author").Value != "me" || r.Field("globs").Line != 4 || r.BodyLine != 8 {
+		t.Errorf("Fields = %+v, BodyLine = %d", r.Fields, r.BodyLine)
+	}
+	if r.Field("alwaysApply") != nil {
+		t.Error("설정되지 않은 키는 nil이어야 합니다")
+	}
+}
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		edit    func(r *Rule)
+		want    string
+	}{
+		{
+			name:    "changed keys only",
+			content: "---\n# owner: platform\ndescription: Old\nglobs:\n  - \"*.go\"\nauthor: me\nalwaysApply: false\n---\nbody\n",
+			edit: func(r *Rule) {
+				r.Globs = []string{"*.go", "go.mod"}
+				r.AlwaysApply = true
+			},
+			want: "---\n# owner: platform\ndescription: Old\nglobs: *.go,go.mod\nauthor: me\nalwaysApply: true\n---\nbody\n",
+		},
+		{
+			name:    "missing key and CRLF",
+			content: "---\r\ndescription: x\r\n---\r\nbody\r\n",
+			edit:    func(r *Rule) { r.Description = "API: v2" },
+			want:    "---\r\ndescription: \"API: v2\"\r\n---\r\nbody\r\n",
+		},
+		{
+			name:    "body of a rule without frontmatter",
+			content: "old\n",
+			edit:    func(r *Rule) { r.Body = "new\n" },
+			want:    "new\n",
+		},
+		{
+			name:    "frontmatter added",
+			content: "body\n",
+			edit:    func(r *Rule) { r.AlwaysApply = true },
+			want:    "---\nalwaysApply: true\n---\nbody\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse("a.mdc", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("Parse 실패: %v", err)
+			}
+			tt.edit(r)
+			if got := string(r.Bytes()); got != tt.want {
+				t.Errorf("Bytes = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBytesNewRule(t *testing.T) {
+	for _, r := range []*Rule{
+		{Path: "a.mdc", Description: "API: \"v2\"", AlwaysApply: true, Body: "# API\n"},
+		{Path: "b.mdc", Globs: []string{"*.go", "go.mod"}, Body: "body\n"},
+	} {
+		parsed, err := Parse(r.Path, r.Bytes())
+		if err != nil {
+			t.Fatalf("Parse 실패: %v", err)
+		}
+		if parsed.Description != r.Description || !reflect.DeepEqual(parsed.Globs, r.Globs) || parsed.AlwaysApply != r.AlwaysApply || parsed.Body != r.Body {
+			t.Errorf("Parse(Bytes) = %+v; want %+v", parsed, r)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"b.mdc":          "---\nalwaysApply: true\n---\nbody\n",
+		"sub/a.mdc":      "---\nglobs: *.go\n---\nbody\n",
+		"sub/broken.mdc": "---\ndescription: x\n",
+		"notes.txt":      "not a rule",
+	} {
+		os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755)
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(content), 0644); err != nil {
+			t.Fatalf("파일 생성 실패: %v", err)
+		}
+	}
+
+	rules, broken, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load 실패: %v", err)
+	}
+	if len(rules) != 2 || rules[0].Path != "b.mdc" || rules[1].Path != "sub/a.mdc" {
+		t.Errorf("rules = %+v; want b.mdc and sub/a.mdc", rules)
+	}
+	var parseErr *ParseError
+	if len(broken) != 1 || !errors.As(broken[0], &parseErr) || parseErr.Path != "sub/broken.mdc" {
+		t.Errorf("broken = %v; want a ParseError for sub/broken.mdc", broken)
+	}
+
+	if _, _, err := Load(filepath.Join(dir, "missing")); err == nil {
+		t.Error("존재하지 않는 디렉토리에 대해 에러가 발생해야 합니다")
+	}
+}
@@ -0,0 +1,280 @@
+// Package s3 is a minimal client for S3-compatible object storage such as Amazon S3 or MinIO.
+// It implements only the object and listing calls used by rulesctl.
+package s3
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when an object or object version does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Client sends requests to a single bucket. Requests use path-style addressing
+// (<endpoint>/<bucket>/<key>), which every S3-compatible server supports.
+type Client struct {
+	endpoint   string // e.g. https://s3.us-east-1.amazonaws.com or http://localhost:9000
+	bucket     string
+	region     string
+	creds      Credentials
+	httpClient *http.Client
+}
+
+// Object is an entry of a bucket listing.
+type Object struct {
+	Key          string
+	ETag         string
+	Size         int64
+	LastModified time.Time
+}
+
+// ObjectVersion is a version of an object in a versioned bucket.
+// Buckets without versioning report a single version with ID "null".
+type ObjectVersion struct {
+	Key          string
+	VersionID    string
+	ETag         string
+	IsLatest     bool
+	LastModified time.Time
+}
+
+// ObjectInfo is returned when reading or writing an object.
+type ObjectInfo struct {
+	VersionID    string
+	LastModified time.Time
+}
+
+// NewClient returns a client for bucket on the S3-compatible server at endpoint.
+func NewClient(endpoint, bucket, region string, creds Credentials) *Client {
+	return &Client{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		bucket:     bucket,
+		region:     region,
+		creds:      creds,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// GetObject returns the content of an object. An empty versionID reads the latest version.
+func (c *Client) GetObject(key, versionID string) ([]byte, *ObjectInfo, error) {
+	query := url.Values{}
+	if versionID != "" {
+		query.Set("versionId", versionID)
+	}
+	resp, err := c.do("GET", key, query, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, objectInfo(resp), nil
+}
+
+// PutObject stores an object.
+func (c *Client) PutObject(key string, data []byte) (*ObjectInfo, error) {
+	resp, err := c.do("PUT", key, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return objectInfo(resp), nil
+}
+
+// DeleteObject deletes an object. In a versioned bucket this adds a delete marker
+// and keeps the previous versions.
+func (c *Client) DeleteObject(key string) error {
+	resp, err := c.do("DELETE", key, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// ListObjects returns the objects whose keys start with prefix.
+// If delimiter is set, keys containing it after the prefix are grouped and
+// returned as common prefixes instead.
+func (c *Client) ListObjects(prefix, delimiter string) ([]Object, []string, error) {
+	var objects []Object
+	var prefixes []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if delimiter != "" {
+			query.Set("delimiter", delimiter)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		var result struct {
+			Contents []struct {
+				Key          string    `xml:"Key"`
+				ETag         string    `xml:"ETag"`
+				Size         int64     `xml:"Size"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Contents"`
+			CommonPrefixes []struct {
+				Prefix string `xml:"Prefix"`
+			} `xml:"CommonPrefixes"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		if err := c.list(query, &result); err != nil {
+			return nil, nil, err
+		}
+
+		for _, o := range result.Contents {
+			objects = append(objects, Object{Key: o.Key, ETag: trimETag(o.ETag), Size: o.Size, LastModified: o.LastModified})
+		}
+		for _, p := range result.CommonPrefixes {
+			prefixes = append(prefixes, p.Prefix)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, prefixes, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// ListObjectVersions returns the versions of the object key, newest first.
+// Delete markers are not included.
+func (c *Client) ListObjectVersions(key string) ([]ObjectVersion, error) {
+	var versions []ObjectVersion
+	keyMarker, versionMarker := "", ""
+	for {
+		query := url.Values{"versions": {""}, "prefix": {key}}
+		if keyMarker != "" {
+			query.Set("key-marker", keyMarker)
+			query.Set("version-id-marker", versionMarker)
+		}
+
+		var result struct {
+			Versions []struct {
+				Key          string    `xml:"Key"`
+				VersionID    string    `xml:"VersionId"`
+				ETag         string    `xml:"ETag"`
+				IsLatest     bool      `xml:"IsLatest"`
+				LastModified time.Time `xml:"LastModified"`
+			} `xml:"Version"`
+			IsTruncated         bool   `xml:"IsTruncated"`
+			NextKeyMarker       string `xml:"NextKeyMarker"`
+			NextVersionIDMarker string `xml:"NextVersionIdMarker"`
+		}
+		if err := c.list(query, &result); err != nil {
+			return nil, err
+		}
+
+		for _, v := range result.Versions {
+			// The prefix also matches longer keys
+			if v.Key != key {
+				continue
+			}
+			versions = append(versions, ObjectVersion{
+				Key:          v.Key,
+				VersionID:    v.VersionID,
+				ETag:         trimETag(v.ETag),
+				IsLatest:     v.IsLatest,
+				LastModified: v.LastModified,
+			})
+		}
+		if !result.IsTruncated || result.NextKeyMarker == "" {
+			break
+		}
+		keyMarker, versionMarker = result.NextKeyMarker, result.NextVersionIDMarker
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].IsLatest != versions[j].IsLatest {
+			return versions[i].IsLatest
+		}
+		return versions[i].LastModified.After(versions[j].LastModified)
+	})
+	return versions, nil
+}
+
+// list sends a bucket listing request and decodes the XML response into out.
+func (c *Client) list(query url.Values, out interface{}) error {
+	resp, err := c.do("GET", "", query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := xml.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// do sends a signed request for key (the bucket itself if key is empty).
+// Responses other than 2xx are returned as errors.
+func (c *Client) do(method, key string, query url.Values, body []byte) (*http.Response, error) {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + c.bucket
+	if key != "" {
+		u.Path += "/" + key
+	}
+	u.RawPath = canonicalURI(u)
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+	if c.creds.AccessKeyID != "" {
+		sign(req, c.creds, c.region, hashHex(body), time.Now())
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	var apiErr struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	xml.NewDecoder(resp.Body).Decode(&apiErr)
+
+	if resp.StatusCode == http.StatusNotFound && apiErr.Code != "NoSuchBucket" {
+		return nil, fmt.Errorf("%s: %w", key, ErrNotFound)
+	}
+	if apiErr.Code != "" {
+		return nil, fmt.Errorf("request failed: %s (%s: %s)", resp.Status, apiErr.Code, apiErr.Message)
+	}
+	return nil, fmt.Errorf("request failed: %s", resp.Status)
+}
+
+func objectInfo(resp *http.Response) *ObjectInfo {
+	info := &ObjectInfo{VersionID: resp.Header.Get("X-Amz-Version-Id")}
+	if t, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.LastModified = t
+	}
+	return info
+}
+
+// trimETag removes the quotes around an ETag.
+func trimETag(etag string) string {
+	return strings.Trim(etag, `"`)
+}
@@ -0,0 +1,42 @@
+// Package tokens estimates how many tokens a text uses in the context of a language model.
+//
+// The estimate approximates BPE tokenizers without their vocabulary: English words
+// take about one token per four characters, punctuation and symbols one token each,
+// and characters of other scripts, such as Hangul or CJK, about one token each.
+package tokens
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// charsPerToken is the average length of a token within a word of Latin letters and digits
+const charsPerToken = 4
+
+// Estimate returns the estimated number of tokens of text.
+func Estimate(text string) int {
+	count := 0
+	word := 0 // Length of the current word of ASCII letters and digits
+	flush := func() {
+		count += (word + charsPerToken - 1) / charsPerToken
+		word = 0
+	}
+
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		text = text[size:]
+
+		switch {
+		case r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			word++
+		case unicode.IsSpace(r):
+			// Whitespace is mostly merged into the following token
+			flush()
+		default:
+			flush()
+			count++
+		}
+	}
+	flush()
+	return count
+}
@@ -0,0 +1,26 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"Use ruff.", 3},              // use, ruff, .
+		{"internationalization", 5},   // 20 characters
+		{"- Run `go test ./...`", 11}, // 4 words and 7 symbols
+		{"코드 스타일", 5},                 // one token per Hangul syllable
+		{strings.Repeat("word ", 100), 100},
+	}
+
+	for _, tt := range tests {
+		if got := Estimate(tt.text); got != tt.want {
+			t.Errorf("Estimate(%q) = %d; want %d", tt.text, got, tt.want)
+		}
+	}
+}
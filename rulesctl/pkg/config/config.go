@@ -3,14 +3,48 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 )
 
+// DefaultAPIURL is the API base URL of github.com
+const DefaultAPIURL = "https://api.github.com"
+
+// DefaultGitLabURL is the URL of gitlab.com
+const DefaultGitLabURL = "https://gitlab.com"
+
+// DefaultS3Region is the region used when none is configured
+const DefaultS3Region = "us-east-1"
+
+// DefaultTokenBudget is the estimated number of tokens always applied rules may add to every request
+const DefaultTokenBudget = 4000
+
 // Config represents rulesctl configuration
 type Config struct {
-	Token    string `json:"token"`
-	LastUsed string `json:"last_used"`
+	Token     string            `json:"token"`                // Token for github.com, or the token of the configured host after LoadConfig
+	Tokens    map[string]string `json:"tokens,omitempty"`     // Tokens of GitHub Enterprise Server and GitLab hosts, keyed by host name
+	APIURL    string            `json:"api_url,omitempty"`    // GitHub API base URL (default: https://api.github.com)
+	UploadURL string            `json:"upload_url,omitempty"` // GitHub upload base URL (default: derived from api_url)
+	LastUsed  string            `json:"last_used"`
+	Backend   string            `json:"backend,omitempty"`    // Storage backend for rule sets (default: gist)
+	FSRoot    string            `json:"fs_root,omitempty"`    // Root directory of the fs backend (default: ~/.rulesctl/registry)
+	GitRepo   string            `json:"git_repo,omitempty"`   // Repository path or URL of the git backend
+	GitBranch string            `json:"git_branch,omitempty"` // Branch the git backend pushes changes to for review (default: the default branch)
+
+	GitLabURL     string `json:"gitlab_url,omitempty"`     // GitLab instance of the gitlab backend (default: https://gitlab.com)
+	GitLabProject string `json:"gitlab_project,omitempty"` // Project ID or path for project snippets (default: personal snippets)
+
+	S3Endpoint string `json:"s3_endpoint,omitempty"` // Endpoint of the s3 backend (default: Amazon S3 in s3_region)
+	S3Bucket   string `json:"s3_bucket,omitempty"`   // Bucket of the s3 backend
+	S3Region   string `json:"s3_region,omitempty"`   // Region of the bucket (default: us-east-1)
+	S3Prefix   string `json:"s3_prefix,omitempty"`   // Key prefix of rule sets in the bucket (default: none)
+
+	RegistryURL string `json:"registry_url,omitempty"` // URL of the static registry read by the http backend
+
+	TokenBudget int `json:"token_budget,omitempty"` // Token budget of always applied rules checked by stats (default: 4000)
 }
 
 var (
@@ -31,9 +65,9 @@ func init() {
 		}
 		configDir = filepath.Join(homeDir, ".rulesctl")
 	}
-	
+
 	configFile = filepath.Join(configDir, "config.json")
-	
+
 	if err := os.MkdirAll(configDir, 0700); err != nil {
 		fmt.Printf("failed to create config directory: %v\n", err)
 		os.Exit(1)
@@ -56,27 +90,89 @@ func EnsureConfigDir() error {
 }
 
 // LoadConfig loads configuration
+// Token is set to the token stored for the host of the configured API URL.
+// Environment variables take precedence over the config file:
+//   - GITHUB_TOKEN overrides the token
+//   - GITHUB_API_URL and GITHUB_UPLOAD_URL override the GitHub API and upload base URLs
+//   - RULESCTL_BACKEND overrides the storage backend
+//   - RULESCTL_FS_ROOT overrides the root directory of the fs backend
+//   - RULESCTL_GIT_REPO and RULESCTL_GIT_BRANCH override the repository and push branch of the git backend
+//   - GITLAB_URL and GITLAB_PROJECT override the GitLab instance and project of the gitlab backend
+//   - RULESCTL_S3_ENDPOINT, RULESCTL_S3_BUCKET, RULESCTL_S3_PREFIX and AWS_REGION override the s3 backend settings
+//   - RULESCTL_REGISTRY_URL overrides the static registry of the http backend
 func LoadConfig() (*Config, error) {
-	// 1. Check token in environment variable
+	config, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if apiURL := os.Getenv("GITHUB_API_URL"); apiURL != "" {
+		config.APIURL = apiURL
+	}
+	if uploadURL := os.Getenv("GITHUB_UPLOAD_URL"); uploadURL != "" {
+		config.UploadURL = uploadURL
+	}
+
+	// Tokens are scoped per host, so a github.com token is never sent to another host
+	if config.IsEnterprise() {
+		config.Token = config.Tokens[config.APIHost()]
+	}
 	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-		return &Config{Token: token}, nil
+		config.Token = token
+	}
+	if backend := os.Getenv("RULESCTL_BACKEND"); backend != "" {
+		config.Backend = backend
+	}
+	if root := os.Getenv("RULESCTL_FS_ROOT"); root != "" {
+		config.FSRoot = root
+	}
+	if repo := os.Getenv("RULESCTL_GIT_REPO"); repo != "" {
+		config.GitRepo = repo
+	}
+	if branch := os.Getenv("RULESCTL_GIT_BRANCH"); branch != "" {
+		config.GitBranch = branch
 	}
+	if gitlabURL := os.Getenv("GITLAB_URL"); gitlabURL != "" {
+		config.GitLabURL = gitlabURL
+	}
+	if project := os.Getenv("GITLAB_PROJECT"); project != "" {
+		config.GitLabProject = project
+	}
+	if endpoint := os.Getenv("RULESCTL_S3_ENDPOINT"); endpoint != "" {
+		config.S3Endpoint = endpoint
+	}
+	if bucket := os.Getenv("RULESCTL_S3_BUCKET"); bucket != "" {
+		config.S3Bucket = bucket
+	}
+	if prefix := os.Getenv("RULESCTL_S3_PREFIX"); prefix != "" {
+		config.S3Prefix = prefix
+	}
+	if region := os.Getenv("AWS_REGION"); region != "" {
+		config.S3Region = region
+	}
+	if registryURL := os.Getenv("RULESCTL_REGISTRY_URL"); registryURL != "" {
+		config.RegistryURL = registryURL
+	}
+
+	return config, nil
+}
 
-	// 2. Check token in config file
+// loadConfigFile loads configuration from the config file only
+func loadConfigFile() (*Config, error) {
 	configPath, err := getConfigPath()
 	if err != nil {
 		return nil, err
 	}
 
+	var config Config
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return &Config{}, nil
+			return &config, nil
 		}
 		return nil, err
 	}
 
-	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
 		return nil, err
 	}
@@ -110,11 +206,144 @@ func SaveToken(token string) error {
 		return fmt.Errorf("token is empty")
 	}
 
-	config, err := LoadConfig()
+	// Environment overrides must not be persisted
+	config, err := loadConfigFile()
 	if err != nil {
 		return err
 	}
 
+	// The token belongs to the host of the API URL in use, which may be set by environment
+	effective, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if effective.IsEnterprise() {
+		return SaveHostToken(effective.APIHost(), token)
+	}
+
 	config.Token = token
 	return SaveConfig(config)
-} 
\ No newline at end of file
+}
+
+// SaveHostToken saves the token of a GitHub Enterprise Server or GitLab host
+func SaveHostToken(host, token string) error {
+	if token == "" {
+		return fmt.Errorf("token is empty")
+	}
+
+	config, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	if config.Tokens == nil {
+		config.Tokens = make(map[string]string)
+	}
+	config.Tokens[strings.ToLower(host)] = token
+	return SaveConfig(config)
+}
+
+// APIBaseURL returns the GitHub API base URL without a trailing slash.
+func (c *Config) APIBaseURL() string {
+	if c.APIURL == "" {
+		return DefaultAPIURL
+	}
+	return strings.TrimSuffix(c.APIURL, "/")
+}
+
+// UploadBaseURL returns the GitHub upload base URL.
+// For GitHub Enterprise Server it defaults to /api/uploads/ on the API host.
+func (c *Config) UploadBaseURL() string {
+	if c.UploadURL != "" {
+		return c.UploadURL
+	}
+	if c.APIURL == "" {
+		return "https://uploads.github.com/"
+	}
+	u, err := url.Parse(c.APIURL)
+	if err != nil || u.Host == "" {
+		return c.APIURL
+	}
+	return u.Scheme + "://" + u.Host + "/api/uploads/"
+}
+
+// GitLabBaseURL returns the base URL of the GitLab instance without a trailing slash.
+func (c *Config) GitLabBaseURL() string {
+	if c.GitLabURL == "" {
+		return DefaultGitLabURL
+	}
+	return strings.TrimSuffix(c.GitLabURL, "/")
+}
+
+// GitLabHost returns the host name of the GitLab instance.
+func (c *Config) GitLabHost() string {
+	return apiHost(c.GitLabBaseURL())
+}
+
+// GitLabToken returns the token for the GitLab instance.
+// The GITLAB_TOKEN environment variable takes precedence over the stored token.
+func (c *Config) GitLabToken() string {
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		return token
+	}
+	return c.Tokens[c.GitLabHost()]
+}
+
+// S3RegionName returns the region of the s3 backend bucket.
+func (c *Config) S3RegionName() string {
+	if c.S3Region == "" {
+		return DefaultS3Region
+	}
+	return c.S3Region
+}
+
+// S3EndpointURL returns the endpoint of the s3 backend without a trailing slash.
+// Defaults to Amazon S3 in the configured region.
+func (c *Config) S3EndpointURL() string {
+	if c.S3Endpoint == "" {
+		return "https://s3." + c.S3RegionName() + ".amazonaws.com"
+	}
+	return strings.TrimSuffix(c.S3Endpoint, "/")
+}
+
+// S3Credentials returns the access keys of the s3 backend.
+// Keys are never stored in the config file; they are taken from the standard
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables.
+func (c *Config) S3Credentials() (accessKeyID, secretAccessKey, sessionToken string) {
+	return os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"), os.Getenv("AWS_SESSION_TOKEN")
+}
+
+// IsEnterprise reports whether a GitHub Enterprise Server is configured instead of github.com.
+func (c *Config) IsEnterprise() bool {
+	return c.APIHost() != apiHost(DefaultAPIURL)
+}
+
+// APIHost returns the host name of the GitHub API base URL.
+func (c *Config) APIHost() string {
+	return apiHost(c.APIBaseURL())
+}
+
+func apiHost(apiURL string) string {
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Host == "" {
+		return apiURL
+	}
+	return strings.ToLower(u.Host)
+}
+
+// Budget returns the token budget of always applied rules.
+// The RULESCTL_TOKEN_BUDGET environment variable overrides the configured budget. It is read
+// here rather than in LoadConfig, so an invalid value only affects the commands that use it.
+func (c *Config) Budget() (int, error) {
+	if budget := os.Getenv("RULESCTL_TOKEN_BUDGET"); budget != "" {
+		n, err := strconv.Atoi(budget)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid RULESCTL_TOKEN_BUDGET: %s", budget)
+		}
+		return n, nil
+	}
+	if c.TokenBudget <= 0 {
+		return DefaultTokenBudget, nil
+	}
+	return c.TokenBudget, nil
+}
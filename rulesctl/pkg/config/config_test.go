@@ -82,4 +82,112 @@ func TestConfigOperations(t *testing.T) {
 			}
 		})
 	}
-} 
\ No newline at end of file
+}
+
+func TestEnterpriseTokens(t *testing.T) {
+	tempDir := t.TempDir()
+	oldConfigDir := configDir
+	oldConfigFile := configFile
+	configDir = tempDir
+	configFile = filepath.Join(tempDir, "config.json")
+	defer func() {
+		configDir = oldConfigDir
+		configFile = oldConfigFile
+	}()
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_API_URL", "")
+
+	// github.com 토큰 저장
+	if err := SaveToken("dotcom-token"); err != nil {
+		t.Fatalf("SaveToken 실패: %v", err)
+	}
+
+	// GitHub Enterprise Server 토큰 저장
+	t.Setenv("GITHUB_API_URL", "https://GHE.example.com/api/v3/")
+	if err := SaveToken("ghe-token"); err != nil {
+		t.Fatalf("SaveToken 실패: %v", err)
+	}
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig 실패: %v", err)
+	}
+	if !cfg.IsEnterprise() || cfg.APIHost() != "ghe.example.com" {
+		t.Errorf("APIHost = %s, IsEnterprise = %v; want ghe.example.com, true", cfg.APIHost(), cfg.IsEnterprise())
+	}
+	if cfg.Token != "ghe-token" {
+		t.Errorf("GHE 토큰 = %s; want ghe-token", cfg.Token)
+	}
+	if cfg.APIBaseURL() != "https://GHE.example.com/api/v3" {
+		t.Errorf("APIBaseURL = %s", cfg.APIBaseURL())
+	}
+	if cfg.UploadBaseURL() != "https://GHE.example.com/api/uploads/" {
+		t.Errorf("UploadBaseURL = %s", cfg.UploadBaseURL())
+	}
+
+	// 다른 호스트에는 github.com 토큰을 사용하지 않음
+	t.Setenv("GITHUB_API_URL", "https://other.example.com/api/v3")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig 실패: %v", err)
+	}
+	if cfg.Token != "" {
+		t.Errorf("설정되지 않은 호스트의 토큰 = %s; want empty", cfg.Token)
+	}
+
+	t.Setenv("GITHUB_API_URL", "")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig 실패: %v", err)
+	}
+	if cfg.IsEnterprise() || cfg.Token != "dotcom-token" {
+		t.Errorf("github.com 토큰 = %s (enterprise: %v); want dotcom-token", cfg.Token, cfg.IsEnterprise())
+	}
+	if cfg.UploadBaseURL() != "https://uploads.github.com/" {
+		t.Errorf("UploadBaseURL = %s", cfg.UploadBaseURL())
+	}
+}
+
+func TestTokenBudget(t *testing.T) {
+	tempDir := t.TempDir()
+	oldConfigDir := configDir
+	oldConfigFile := configFile
+	configDir = tempDir
+	configFile = filepath.Join(tempDir, "config.json")
+	defer func() {
+		configDir = oldConfigDir
+		configFile = oldConfigFile
+	}()
+	t.Setenv("RULESCTL_TOKEN_BUDGET", "")
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig 실패: %v", err)
+	}
+	if budget, err := cfg.Budget(); err != nil || budget != DefaultTokenBudget {
+		t.Errorf("기본 예산 = %d, %v; want %d", budget, err, DefaultTokenBudget)
+	}
+
+	if err := SaveConfig(&Config{TokenBudget: 2000}); err != nil {
+		t.Fatalf("SaveConfig 실패: %v", err)
+	}
+	if cfg, err = LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig 실패: %v", err)
+	}
+	if budget, err := cfg.Budget(); err != nil || budget != 2000 {
+		t.Errorf("설정 파일 예산 = %d, %v; want 2000", budget, err)
+	}
+	t.Setenv("RULESCTL_TOKEN_BUDGET", "1500")
+	if budget, err := cfg.Budget(); err != nil || budget != 1500 {
+		t.Errorf("환경 변수 예산 = %d, %v; want 1500", budget, err)
+	}
+
+	// 잘못된 값은 예산을 사용하는 명령에서만 에러
+	t.Setenv("RULESCTL_TOKEN_BUDGET", "many")
+	if cfg, err = LoadConfig(); err != nil {
+		t.Fatalf("잘못된 RULESCTL_TOKEN_BUDGET 때문에 LoadConfig가 실패했습니다: %v", err)
+	}
+	if _, err := cfg.Budget(); err == nil {
+		t.Error("잘못된 RULESCTL_TOKEN_BUDGET에 대해 에러가 발생해야 합니다")
+	}
+}